@@ -0,0 +1,75 @@
+package ebroadcast
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unwrap(t *testing.T) {
+	ev := Event{
+		Type: EventTypeBroadcastStarted,
+		Broadcast: BroadcastData{
+			Id:        55,
+			StartedAt: time.Date(1997, 9, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+	t.Run("enveloped event", func(t *testing.T) {
+		data, err := json.Marshal(Wrap(ev, "broadcasts"))
+		assert.NoError(t, err)
+
+		got, err := Unwrap(data)
+		assert.NoError(t, err)
+		assert.Equal(t, "broadcasts", got.Producer)
+		assert.Equal(t, SchemaVersion, got.SchemaVersion)
+		assert.Equal(t, ev, got.Body)
+	})
+	t.Run("bare event", func(t *testing.T) {
+		data, err := json.Marshal(ev)
+		assert.NoError(t, err)
+
+		got, err := Unwrap(data)
+		assert.NoError(t, err)
+		assert.True(t, got.IsBare())
+		assert.Equal(t, ev, got.Body)
+	})
+}
+
+func Test_UnwrapStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{
+			"broadcast started",
+			`{"type":"broadcast-started","broadcast":{"id":55,"started_at":"1997-09-01T12:00:00Z"}}`,
+			nil,
+		},
+		{
+			"unknown event type",
+			`{"type":"broadcast-paused","broadcast":{"id":55,"started_at":"1997-09-01T12:00:00Z"}}`,
+			core.ErrUnknownType,
+		},
+		{
+			"screening event without screening data",
+			`{"type":"screening-started","broadcast":{"id":55,"started_at":"1997-09-01T12:00:00Z"}}`,
+			core.ErrMissingPayload,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnwrapStrict([]byte(tt.data))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package ebroadcast
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/golden-vcr/schemas/core"
@@ -24,6 +25,15 @@ const (
 	EventTypeScreeningFinished EventType = "screening-finished"
 )
 
+func (EventType) EnumValues() []string {
+	return []string{
+		string(EventTypeBroadcastStarted),
+		string(EventTypeBroadcastFinished),
+		string(EventTypeScreeningStarted),
+		string(EventTypeScreeningFinished),
+	}
+}
+
 // BroadcastData describes the broadcast in which this event is occurring
 type BroadcastData struct {
 	Id        int       `json:"id"`
@@ -38,6 +48,28 @@ type ScreeningData struct {
 	TapeId    int       `json:"tape_id"`
 }
 
+// UnmarshalJSONStrict decodes an Event in strict mode, failing with core.ErrUnknownType
+// if the event type is not recognized, or with core.ErrMissingPayload if a screening
+// event carries no screening data
+func (ev *Event) UnmarshalJSONStrict(data []byte) error {
+	type fields Event
+	var f fields
+	if err := core.DecodeJSON(data, &f, true); err != nil {
+		return err
+	}
+	switch f.Type {
+	case EventTypeBroadcastStarted, EventTypeBroadcastFinished:
+	case EventTypeScreeningStarted, EventTypeScreeningFinished:
+		if f.Screening == nil {
+			return core.ErrMissingPayload
+		}
+	default:
+		return fmt.Errorf("%w: event type '%s'", core.ErrUnknownType, f.Type)
+	}
+	*ev = Event(f)
+	return nil
+}
+
 func (ev *Event) ToState(prev core.State) core.State {
 	switch ev.Type {
 	case EventTypeBroadcastStarted:
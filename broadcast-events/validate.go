@@ -0,0 +1,44 @@
+package ebroadcast
+
+import (
+	"fmt"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/google/uuid"
+)
+
+// Validate verifies that an Event is well-formed: its type must be recognized, it must
+// identify the broadcast in which it occurs, and screening events (and only screening
+// events) must identify the screening
+func (ev Event) Validate() error {
+	var v core.Validator
+	v.Nested("/broadcast", ev.Broadcast.Validate())
+	switch ev.Type {
+	case EventTypeBroadcastStarted, EventTypeBroadcastFinished:
+		v.Check(ev.Screening == nil, "/screening", fmt.Sprintf("must not be set for type '%s'", ev.Type))
+	case EventTypeScreeningStarted, EventTypeScreeningFinished:
+		if ev.Screening != nil {
+			v.Nested("/screening", ev.Screening.Validate())
+		} else {
+			v.Check(false, "/screening", fmt.Sprintf("is required for type '%s'", ev.Type))
+		}
+	default:
+		v.Check(false, "/type", fmt.Sprintf("unknown type '%s'", ev.Type))
+	}
+	return v.Err()
+}
+
+func (d BroadcastData) Validate() error {
+	var v core.Validator
+	v.Check(d.Id > 0, "/id", "must be a positive number")
+	v.Check(!d.StartedAt.IsZero(), "/started_at", "is required")
+	return v.Err()
+}
+
+func (d ScreeningData) Validate() error {
+	var v core.Validator
+	v.Check(d.Id != uuid.Nil, "/id", "is required")
+	v.Check(!d.StartedAt.IsZero(), "/started_at", "is required")
+	v.Check(d.TapeId > 0, "/tape_id", "must be a positive number")
+	return v.Err()
+}
@@ -0,0 +1,74 @@
+package ebroadcast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Event_Validate(t *testing.T) {
+	broadcast := BroadcastData{
+		Id:        55,
+		StartedAt: time.Date(1997, 9, 1, 12, 0, 0, 0, time.UTC),
+	}
+	screening := &ScreeningData{
+		Id:        uuid.MustParse("f29a4ffe-cb9f-43ba-9f91-a3b1fa350472"),
+		StartedAt: time.Date(1997, 9, 1, 12, 15, 0, 0, time.UTC),
+		TapeId:    109,
+	}
+	tests := []struct {
+		name    string
+		ev      Event
+		wantErr core.ValidationErrors
+	}{
+		{
+			"broadcast started",
+			Event{Type: EventTypeBroadcastStarted, Broadcast: broadcast},
+			nil,
+		},
+		{
+			"screening started",
+			Event{Type: EventTypeScreeningStarted, Broadcast: broadcast, Screening: screening},
+			nil,
+		},
+		{
+			"unknown event type",
+			Event{Type: "broadcast-paused", Broadcast: broadcast},
+			core.ValidationErrors{{Path: "/type", Message: "unknown type 'broadcast-paused'"}},
+		},
+		{
+			"screening started with no screening",
+			Event{Type: EventTypeScreeningStarted, Broadcast: broadcast},
+			core.ValidationErrors{{Path: "/screening", Message: "is required for type 'screening-started'"}},
+		},
+		{
+			"broadcast started with screening",
+			Event{Type: EventTypeBroadcastStarted, Broadcast: broadcast, Screening: screening},
+			core.ValidationErrors{{Path: "/screening", Message: "must not be set for type 'broadcast-started'"}},
+		},
+		{
+			"screening finished with empty broadcast and screening",
+			Event{Type: EventTypeScreeningFinished, Screening: &ScreeningData{}},
+			core.ValidationErrors{
+				{Path: "/broadcast/id", Message: "must be a positive number"},
+				{Path: "/broadcast/started_at", Message: "is required"},
+				{Path: "/screening/id", Message: "is required"},
+				{Path: "/screening/started_at", Message: "is required"},
+				{Path: "/screening/tape_id", Message: "must be a positive number"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ev.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Command jsonschema generates a JSON Schema document describing the messages that are
+// produced to each queue, i.e. a core.Envelope whose body is that queue's message type.
+// From the root of the repo, run:
+//
+//	go run ./cmd/jsonschema
+//
+// The resulting files are committed to the jsonschema directory, so that non-Go
+// consumers have a machine-readable contract for each queue.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/golden-vcr/schemas/internal/schemagen"
+	"github.com/golden-vcr/schemas/registry"
+)
+
+func main() {
+	outDir := flag.String("out", "jsonschema", "Directory to which schema files will be written")
+	flag.Parse()
+
+	docs, err := generate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to generate JSON Schema: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create output directory: %v\n", err)
+		os.Exit(1)
+	}
+	for filename, data := range docs {
+		path := filepath.Join(*outDir, filename)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote %s\n", path)
+	}
+}
+
+// generate returns the contents of the JSON Schema file for each registered queue,
+// keyed by filename
+func generate() (map[string][]byte, error) {
+	docs := make(map[string][]byte)
+	for _, schema := range registry.Schemas() {
+		model, err := schemagen.BuildEnvelope(schema.Root)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", schema.Queue, err)
+		}
+		data, err := schemagen.RenderJSONSchema(string(schema.Queue), model)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", schema.Queue, err)
+		}
+		docs[string(schema.Queue)+".schema.json"] = data
+	}
+	return docs, nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_generate(t *testing.T) {
+	// If this test fails, the Go types have changed without the committed schema files
+	// being updated: run 'go run ./cmd/jsonschema' from the root of the repo
+	docs, err := generate()
+	assert.NoError(t, err)
+
+	committed, err := filepath.Glob(filepath.Join("..", "..", "jsonschema", "*.schema.json"))
+	assert.NoError(t, err)
+	assert.Len(t, committed, len(docs))
+
+	for filename, want := range docs {
+		got, err := os.ReadFile(filepath.Join("..", "..", "jsonschema", filename))
+		if assert.NoError(t, err, "%s is missing", filename) {
+			assert.Equal(t, string(want), string(got), "%s is out of date", filename)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Command schemacompat compares the schema of every queue between two revisions of this
+// module, reporting each change along with its compatibility, and exiting with a
+// non-zero status if any change is breaking. From the root of the repo, run:
+//
+//	go run ./cmd/schemacompat -from main
+//
+// Schemas are read from the JSON Schema files that are committed to the jsonschema
+// directory, at the revision given by -from and at the revision given by -to. If -to is
+// omitted, the files in the working tree are used instead.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golden-vcr/schemas/internal/schemagen"
+)
+
+// schemaDir is the directory, relative to the root of the repo, that contains the
+// JSON Schema file for each queue
+const schemaDir = "jsonschema"
+
+const schemaSuffix = ".schema.json"
+
+func main() {
+	from := flag.String("from", "HEAD", "Git revision containing the old version of the schemas")
+	to := flag.String("to", "", "Git revision containing the new version of the schemas (default: the working tree)")
+	flag.Parse()
+
+	oldDocs, err := readRevision(*from)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read schemas at %s: %v\n", *from, err)
+		os.Exit(2)
+	}
+	var newDocs map[string][]byte
+	if *to == "" {
+		newDocs, err = readWorkingTree(schemaDir)
+	} else {
+		newDocs, err = readRevision(*to)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read new schemas: %v\n", err)
+		os.Exit(2)
+	}
+
+	changes, err := compare(oldDocs, newDocs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to compare schemas: %v\n", err)
+		os.Exit(2)
+	}
+	numBreaking := 0
+	for _, change := range changes {
+		fmt.Println(change)
+		if change.Compatibility == schemagen.CompatibilityBreaking {
+			numBreaking++
+		}
+	}
+	if len(changes) == 0 {
+		fmt.Println("No schema changes.")
+	}
+	if numBreaking > 0 {
+		fmt.Fprintf(os.Stderr, "%d breaking change(s) found.\n", numBreaking)
+		os.Exit(1)
+	}
+}
+
+// queueChange is a change to the schema of a single queue
+type queueChange struct {
+	queue string
+	schemagen.Change
+}
+
+func (c queueChange) String() string {
+	return fmt.Sprintf("%s: %s", c.queue, c.Change)
+}
+
+// compare returns every change between two sets of JSON Schema documents, keyed by
+// filename, ordered by queue name
+func compare(oldDocs map[string][]byte, newDocs map[string][]byte) ([]queueChange, error) {
+	queues := make([]string, 0, len(oldDocs)+len(newDocs))
+	for filename := range oldDocs {
+		queues = append(queues, strings.TrimSuffix(filename, schemaSuffix))
+	}
+	for filename := range newDocs {
+		if _, ok := oldDocs[filename]; !ok {
+			queues = append(queues, strings.TrimSuffix(filename, schemaSuffix))
+		}
+	}
+	sort.Strings(queues)
+
+	var changes []queueChange
+	for _, queue := range queues {
+		oldDoc, inOld := oldDocs[queue+schemaSuffix]
+		newDoc, inNew := newDocs[queue+schemaSuffix]
+		if !inNew {
+			changes = append(changes, queueChange{queue, schemagen.Change{
+				Path:          queue,
+				Description:   "queue removed",
+				Compatibility: schemagen.CompatibilityBreaking,
+			}})
+			continue
+		}
+		if !inOld {
+			changes = append(changes, queueChange{queue, schemagen.Change{
+				Path:          queue,
+				Description:   "queue added",
+				Compatibility: schemagen.CompatibilityFull,
+			}})
+			continue
+		}
+
+		oldModel, err := schemagen.ParseJSONSchema(oldDoc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: old schema: %w", queue, err)
+		}
+		newModel, err := schemagen.ParseJSONSchema(newDoc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: new schema: %w", queue, err)
+		}
+		for _, change := range schemagen.Compare(oldModel, newModel) {
+			changes = append(changes, queueChange{queue, change})
+		}
+	}
+	return changes, nil
+}
+
+// readRevision returns the contents of every JSON Schema file committed at the given
+// git revision, keyed by filename
+func readRevision(rev string) (map[string][]byte, error) {
+	listing, err := git("ls-tree", "--name-only", rev+":"+schemaDir)
+	if err != nil {
+		return nil, err
+	}
+	docs := make(map[string][]byte)
+	for _, filename := range strings.Fields(string(listing)) {
+		if !strings.HasSuffix(filename, schemaSuffix) {
+			continue
+		}
+		data, err := git("show", rev+":"+path.Join(schemaDir, filename))
+		if err != nil {
+			return nil, err
+		}
+		docs[filename] = data
+	}
+	return docs, nil
+}
+
+// readWorkingTree returns the contents of every JSON Schema file in the given directory
+// of the working tree, keyed by filename
+func readWorkingTree(dir string) (map[string][]byte, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+schemaSuffix))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no schema files found in %s; run from the root of the repo", dir)
+	}
+	docs := make(map[string][]byte)
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		docs[filepath.Base(p)] = data
+	}
+	return docs, nil
+}
+
+func git(args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/golden-vcr/schemas/internal/schemagen"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_compare(t *testing.T) {
+	docs, err := readWorkingTree(filepath.Join("..", "..", schemaDir))
+	assert.NoError(t, err)
+
+	t.Run("committed schemas are unchanged when compared to themselves", func(t *testing.T) {
+		changes, err := compare(docs, docs)
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+	t.Run("adding and removing queues is reported", func(t *testing.T) {
+		oldDocs := map[string][]byte{"twitch-events.schema.json": docs["twitch-events.schema.json"]}
+		newDocs := map[string][]byte{"onscreen-events.schema.json": docs["onscreen-events.schema.json"]}
+		changes, err := compare(oldDocs, newDocs)
+		assert.NoError(t, err)
+		assert.Equal(t, []queueChange{
+			{"onscreen-events", schemagen.Change{Path: "onscreen-events", Description: "queue added", Compatibility: schemagen.CompatibilityFull}},
+			{"twitch-events", schemagen.Change{Path: "twitch-events", Description: "queue removed", Compatibility: schemagen.CompatibilityBreaking}},
+		}, changes)
+	})
+}
@@ -0,0 +1,73 @@
+// Command typescript generates TypeScript type declarations for the messages that are
+// produced to the queues consumed by frontend code, including an Envelope interface
+// that wraps each message body in its header. From the root of the repo, run:
+//
+//	go run ./cmd/typescript
+//
+// The resulting files are committed to the typescript directory, so that the graphics
+// app can import them rather than maintaining equivalent types by hand.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/golden-vcr/schemas/internal/schemagen"
+	"github.com/golden-vcr/schemas/registry"
+)
+
+// queues identifies the queues whose messages are consumed by frontend code
+var queues = []registry.Queue{
+	registry.QueueBroadcastEvents,
+	registry.QueueOnscreenEvents,
+}
+
+// header is prepended to every generated file
+const header = "// Code generated by 'go run ./cmd/typescript' in github.com/golden-vcr/schemas. DO NOT EDIT.\n\n"
+
+func main() {
+	outDir := flag.String("out", "typescript", "Directory to which TypeScript files will be written")
+	flag.Parse()
+
+	files, err := generate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to generate TypeScript: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create output directory: %v\n", err)
+		os.Exit(1)
+	}
+	for filename, data := range files {
+		path := filepath.Join(*outDir, filename)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote %s\n", path)
+	}
+}
+
+// generate returns the contents of the TypeScript file for each queue, keyed by
+// filename
+func generate() (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, queue := range queues {
+		schema, ok := registry.Lookup(queue)
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", queue, registry.ErrUnknownQueue)
+		}
+		model, err := schemagen.BuildEnvelope(schema.Root)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", queue, err)
+		}
+		data, err := schemagen.RenderTypeScript(model)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", queue, err)
+		}
+		files[string(queue)+".ts"] = append([]byte(header), data...)
+	}
+	return files, nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_generate(t *testing.T) {
+	// If this test fails, the Go types have changed without the committed TypeScript
+	// files being updated: run 'go run ./cmd/typescript' from the root of the repo
+	files, err := generate()
+	assert.NoError(t, err)
+
+	committed, err := filepath.Glob(filepath.Join("..", "..", "typescript", "*.ts"))
+	assert.NoError(t, err)
+	assert.Len(t, committed, len(files))
+
+	for filename, want := range files {
+		got, err := os.ReadFile(filepath.Join("..", "..", "typescript", filename))
+		if assert.NoError(t, err, "%s is missing", filename) {
+			assert.Equal(t, string(want), string(got), "%s is out of date", filename)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+package core
+
+// Enum is implemented by string types that may only take on a fixed set of values, so
+// that tooling (e.g. schema generators) can enumerate those values
+type Enum interface {
+	EnumValues() []string
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Header carries metadata that identifies a single message produced to a queue,
+// independent of the schema that message's body conforms to.
+//
+// CorrelationId and CausationId allow a chain of messages to be traced across queues:
+// every message that results (directly or indirectly) from the same originating
+// message shares its CorrelationId, and CausationId identifies the immediate parent
+// message that caused this one to be produced. A message that isn't caused by any
+// other message has a CorrelationId equal to its own MessageId and no CausationId, in
+// which case causation_id is omitted.
+type Header struct {
+	MessageId     uuid.UUID  `json:"message_id"`
+	CorrelationId uuid.UUID  `json:"correlation_id"`
+	CausationId   *uuid.UUID `json:"causation_id,omitempty"`
+	ProducedAt    time.Time  `json:"produced_at"`
+	Producer      string     `json:"producer"`
+	SchemaVersion int        `json:"schema_version"`
+}
+
+// IsBare returns true if the message was published without an envelope, i.e. by a
+// producer that predates versioned envelopes, in which case no other header fields are
+// populated
+func (h Header) IsBare() bool {
+	return h.SchemaVersion == 0
+}
+
+// Envelope wraps the body of a message (e.g. an etwitch.Event or a genreq.Request) with
+// a Header describing when, by whom, and according to which schema revision it was
+// produced
+type Envelope[T any] struct {
+	Header
+	Body T `json:"body"`
+}
+
+// NewEnvelope wraps the given message body in a new Envelope with a unique message ID,
+// recording the name of the service that's producing it and the version of the schema
+// that body conforms to
+func NewEnvelope[T any](producer string, schemaVersion int, body T) Envelope[T] {
+	messageId := uuid.New()
+	return Envelope[T]{
+		Header: Header{
+			MessageId:     messageId,
+			CorrelationId: messageId,
+			ProducedAt:    time.Now().UTC(),
+			Producer:      producer,
+			SchemaVersion: schemaVersion,
+		},
+		Body: body,
+	}
+}
+
+// NewChildEnvelope wraps the given message body in a new Envelope, exactly as with
+// NewEnvelope, but records that the message was produced in response to the parent
+// message identified by the given header. A bare parent carries no IDs, in which case
+// the new message is treated as the start of a new chain.
+func NewChildEnvelope[T any](parent Header, producer string, schemaVersion int, body T) Envelope[T] {
+	envelope := NewEnvelope(producer, schemaVersion, body)
+	if parent.MessageId != uuid.Nil {
+		envelope.CorrelationId = parent.CorrelationId
+		if envelope.CorrelationId == uuid.Nil {
+			envelope.CorrelationId = parent.MessageId
+		}
+		causationId := parent.MessageId
+		envelope.CausationId = &causationId
+	}
+	return envelope
+}
+
+// UnmarshalJSON decodes an Envelope, or a bare message body that was published without
+// an envelope: in the latter case, the entire message is decoded as the body and the
+// resulting Header is empty
+func (e *Envelope[T]) UnmarshalJSON(data []byte) error {
+	return e.unmarshal(data, false)
+}
+
+// UnmarshalJSONStrict decodes an Envelope (or a bare message body) exactly as with
+// UnmarshalJSON, except that the body is decoded in strict mode
+func (e *Envelope[T]) UnmarshalJSONStrict(data []byte) error {
+	return e.unmarshal(data, true)
+}
+
+func (e *Envelope[T]) unmarshal(data []byte, strict bool) error {
+	type probe struct {
+		SchemaVersion *int `json:"schema_version"`
+	}
+	var p probe
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	if p.SchemaVersion == nil {
+		e.Header = Header{}
+		return DecodeJSON(data, &e.Body, strict)
+	}
+
+	type fields struct {
+		Header
+		Body json.RawMessage `json:"body"`
+	}
+	var f fields
+	if err := DecodeJSON(data, &f, strict); err != nil {
+		return err
+	}
+	e.Header = f.Header
+	return DecodeJSON(f.Body, &e.Body, strict)
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Envelope(t *testing.T) {
+	causationId := uuid.MustParse("64b1d3a4-3a3c-4d88-a1c5-3f5b5c3c1f11")
+	envelope := Envelope[Viewer]{
+		Header: Header{
+			MessageId:     uuid.MustParse("5bc71c8b-4d42-4b80-8a6b-7fd2bde0b1c2"),
+			CorrelationId: uuid.MustParse("0e0b1bd4-4b71-4a0e-9b8e-bf0b7c64a8a3"),
+			CausationId:   &causationId,
+			ProducedAt:    time.Date(1997, 9, 1, 12, 0, 0, 0, time.UTC),
+			Producer:      "hooks",
+			SchemaVersion: 1,
+		},
+		Body: Viewer{
+			TwitchUserId:      "90790024",
+			TwitchDisplayName: "wasabimilkshake",
+		},
+	}
+	jsonEnvelope := `{"message_id":"5bc71c8b-4d42-4b80-8a6b-7fd2bde0b1c2","correlation_id":"0e0b1bd4-4b71-4a0e-9b8e-bf0b7c64a8a3","causation_id":"64b1d3a4-3a3c-4d88-a1c5-3f5b5c3c1f11","produced_at":"1997-09-01T12:00:00Z","producer":"hooks","schema_version":1,"body":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"}}`
+
+	t.Run("marshal envelope to JSON", func(t *testing.T) {
+		got, err := json.Marshal(envelope)
+		assert.NoError(t, err)
+		assert.Equal(t, jsonEnvelope, string(got))
+	})
+	t.Run("unmarshal envelope from JSON", func(t *testing.T) {
+		var got Envelope[Viewer]
+		err := json.Unmarshal([]byte(jsonEnvelope), &got)
+		assert.NoError(t, err)
+		assert.Equal(t, envelope, got)
+		assert.False(t, got.IsBare())
+	})
+	t.Run("marshal root envelope to JSON without causation ID", func(t *testing.T) {
+		root := envelope
+		root.CausationId = nil
+		got, err := json.Marshal(root)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(got), "causation_id")
+	})
+	t.Run("unmarshal bare message body from JSON", func(t *testing.T) {
+		var got Envelope[Viewer]
+		err := json.Unmarshal([]byte(`{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"}`), &got)
+		assert.NoError(t, err)
+		assert.Equal(t, Envelope[Viewer]{Body: envelope.Body}, got)
+		assert.True(t, got.IsBare())
+	})
+}
+
+func Test_NewEnvelope(t *testing.T) {
+	a := NewEnvelope("dispatch", 3, Viewer{TwitchUserId: "1234"})
+	b := NewEnvelope("dispatch", 3, Viewer{TwitchUserId: "1234"})
+	assert.NotEqual(t, uuid.Nil, a.MessageId)
+	assert.NotEqual(t, a.MessageId, b.MessageId)
+	assert.Equal(t, a.MessageId, a.CorrelationId)
+	assert.Nil(t, a.CausationId)
+	assert.False(t, a.ProducedAt.IsZero())
+	assert.Equal(t, "dispatch", a.Producer)
+	assert.Equal(t, 3, a.SchemaVersion)
+	assert.Equal(t, "1234", a.Body.TwitchUserId)
+}
+
+func Test_NewChildEnvelope(t *testing.T) {
+	t.Run("child of a root message", func(t *testing.T) {
+		root := NewEnvelope("hooks", 1, Viewer{})
+		child := NewChildEnvelope(root.Header, "dispatch", 1, Viewer{})
+		assert.NotEqual(t, root.MessageId, child.MessageId)
+		assert.Equal(t, root.MessageId, child.CorrelationId)
+		assert.Equal(t, &root.MessageId, child.CausationId)
+		assert.Equal(t, "dispatch", child.Producer)
+	})
+	t.Run("grandchild shares correlation ID of root message", func(t *testing.T) {
+		root := NewEnvelope("hooks", 1, Viewer{})
+		child := NewChildEnvelope(root.Header, "dispatch", 1, Viewer{})
+		grandchild := NewChildEnvelope(child.Header, "dynamo", 1, Viewer{})
+		assert.Equal(t, root.MessageId, grandchild.CorrelationId)
+		assert.Equal(t, &child.MessageId, grandchild.CausationId)
+	})
+	t.Run("child of a parent with no correlation ID", func(t *testing.T) {
+		parent := Header{MessageId: uuid.MustParse("5bc71c8b-4d42-4b80-8a6b-7fd2bde0b1c2")}
+		child := NewChildEnvelope(parent, "dispatch", 1, Viewer{})
+		assert.Equal(t, parent.MessageId, child.CorrelationId)
+		assert.Equal(t, &parent.MessageId, child.CausationId)
+	})
+	t.Run("child of a bare message starts a new chain", func(t *testing.T) {
+		child := NewChildEnvelope(Header{}, "dispatch", 1, Viewer{})
+		assert.Equal(t, child.MessageId, child.CorrelationId)
+		assert.Nil(t, child.CausationId)
+	})
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// ErrUnknownType is returned when strictly decoding a message whose type discriminator
+// (e.g. an event type) is not recognized
+var ErrUnknownType = errors.New("unknown type")
+
+// ErrMissingPayload is returned when strictly decoding a message whose type requires a
+// payload, but for which no payload is present
+var ErrMissingPayload = errors.New("missing payload")
+
+// StrictUnmarshaler is implemented by types that support an opt-in strict decoding
+// mode, in which messages with unknown types or missing payloads are rejected with an
+// error rather than being decoded as empty values. Unrecognized fields are ignored in
+// either mode, so that producers can add optional fields without breaking consumers.
+type StrictUnmarshaler interface {
+	UnmarshalJSONStrict(data []byte) error
+}
+
+// DecodeJSON decodes data into v, which must be a pointer. If strict is true and v
+// implements StrictUnmarshaler, its UnmarshalJSONStrict method is used.
+func DecodeJSON(data []byte, v any, strict bool) error {
+	if strict {
+		if u, ok := v.(StrictUnmarshaler); ok {
+			return u.UnmarshalJSONStrict(data)
+		}
+	}
+	return json.Unmarshal(data, v)
+}
+
+// DecodePayload decodes a type-specific payload into v, which must be a pointer to the
+// nil-able pointer field that will hold that payload. A payload that is absent (i.e.
+// empty data, as when the payload key is missing) or null leaves that field nil; if
+// strict is true, it results in ErrMissingPayload, and the payload is decoded strictly.
+func DecodePayload(data json.RawMessage, v any, strict bool) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		if strict {
+			return ErrMissingPayload
+		}
+		return nil
+	}
+	if !strict {
+		return json.Unmarshal(data, v)
+	}
+
+	// Allocate a new value for the pointer that v points to, so that we can call
+	// UnmarshalJSONStrict on it if it's supported
+	field := reflect.ValueOf(v).Elem()
+	value := reflect.New(field.Type().Elem())
+	if err := DecodeJSON(data, value.Interface(), true); err != nil {
+		return err
+	}
+	field.Set(value)
+	return nil
+}
@@ -16,3 +16,41 @@ type State struct {
 	ScreeningId uuid.UUID `json:"screening_id"`
 	TapeId      int       `json:"tape_id"`
 }
+
+// GoalType identifies what a Twitch creator goal counts: subscription goals count the
+// total subscription points (or the number of subscriptions, for subscription_count)
+// across all subscribers, while new_subscription goals count only those added since the
+// goal began
+type GoalType string
+
+const (
+	GoalTypeFollow               GoalType = "follow"
+	GoalTypeSubscription         GoalType = "subscription"
+	GoalTypeSubscriptionCount    GoalType = "subscription_count"
+	GoalTypeNewSubscription      GoalType = "new_subscription"
+	GoalTypeNewSubscriptionCount GoalType = "new_subscription_count"
+	GoalTypeNewBit               GoalType = "new_bit"
+	GoalTypeNewCheerer           GoalType = "new_cheerer"
+)
+
+func (GoalType) EnumValues() []string {
+	return []string{
+		string(GoalTypeFollow),
+		string(GoalTypeSubscription),
+		string(GoalTypeSubscriptionCount),
+		string(GoalTypeNewSubscription),
+		string(GoalTypeNewSubscriptionCount),
+		string(GoalTypeNewBit),
+		string(GoalTypeNewCheerer),
+	}
+}
+
+// PollChoice records the votes cast for one of the choices in a Twitch poll: NumVotes
+// is the total number of votes, including the NumChannelPointsVotes that viewers
+// purchased with channel points
+type PollChoice struct {
+	Id                    string `json:"id"`
+	Title                 string `json:"title"`
+	NumVotes              int    `json:"num_votes"`
+	NumChannelPointsVotes int    `json:"num_channel_points_votes"`
+}
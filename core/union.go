@@ -0,0 +1,273 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Union describes a discriminated union: a struct P with one nil-able pointer field per
+// variant, at most one of which is populated, alongside a discriminator value of type K
+// (e.g. an EventType) which identifies the variant. On the wire, the discriminator is
+// encoded alongside the variant's payload, which is encoded as a bare JSON value.
+//
+// Each variant is registered once against its discriminator value, and the Union then
+// handles marshaling and unmarshaling the payload struct:
+//
+//	var payloadUnion = core.NewUnion[EventType, Payload]("type", "payload").
+//		Empty(EventTypeFollowed).
+//		Variant(EventTypeCheered, func(p *Payload) any { return &p.Cheered })
+type Union[K ~string, P any] struct {
+	discriminator string
+	key           string
+	variants      []unionVariant[K, P]
+	index         map[K]int
+}
+
+type unionVariant[K ~string, P any] struct {
+	tag      K
+	field    func(p *P) any
+	optional bool
+}
+
+// UnionInfo describes the structure of a Union independently of its type parameters,
+// so that tooling (e.g. schema generators) can inspect it
+type UnionInfo interface {
+	// Discriminator returns the JSON key that holds the discriminator value
+	Discriminator() string
+	// Key returns the JSON key that holds the variant's payload
+	Key() string
+	// Variants lists every registered variant, in order of registration
+	Variants() []VariantInfo
+}
+
+// VariantInfo describes a single variant of a Union
+type VariantInfo struct {
+	// Tag is the discriminator value that identifies the variant
+	Tag string
+	// Type is the struct type of the variant's payload, or nil if it has no payload
+	Type reflect.Type
+	// Optional is true if the variant's payload may be omitted
+	Optional bool
+}
+
+// TaggedUnion is implemented by the payload struct of every Union, exposing the
+// structure of that union
+type TaggedUnion interface {
+	Union() UnionInfo
+}
+
+// NewUnion initializes an empty Union, given the JSON keys that are used to encode the
+// discriminator value and the payload in the enclosing object
+func NewUnion[K ~string, P any](discriminator string, key string) *Union[K, P] {
+	return &Union[K, P]{
+		discriminator: discriminator,
+		key:           key,
+		index:         make(map[K]int),
+	}
+}
+
+// Variant registers a discriminator value that's encoded with a payload. The field
+// function must return a pointer to the field of P that holds that payload, e.g.
+// func(p *Payload) any { return &p.Cheered }.
+func (u *Union[K, P]) Variant(tag K, field func(p *P) any) *Union[K, P] {
+	if _, ok := u.index[tag]; ok {
+		panic(fmt.Sprintf("%s '%s' is already registered", u.discriminator, tag))
+	}
+	u.index[tag] = len(u.variants)
+	u.variants = append(u.variants, unionVariant[K, P]{tag: tag, field: field})
+	return u
+}
+
+// Optional registers a discriminator value that's encoded with a payload, exactly as
+// with Variant, except that the payload may be omitted: this allows a payload to be
+// added to a variant that was previously registered with Empty, without rejecting
+// messages from producers that predate the payload
+func (u *Union[K, P]) Optional(tag K, field func(p *P) any) *Union[K, P] {
+	u.Variant(tag, field)
+	u.variants[len(u.variants)-1].optional = true
+	return u
+}
+
+// Empty registers one or more discriminator values that carry no payload
+func (u *Union[K, P]) Empty(tags ...K) *Union[K, P] {
+	for _, tag := range tags {
+		u.Variant(tag, nil)
+	}
+	return u
+}
+
+func (u *Union[K, P]) Discriminator() string {
+	return u.discriminator
+}
+
+func (u *Union[K, P]) Key() string {
+	return u.key
+}
+
+func (u *Union[K, P]) Variants() []VariantInfo {
+	var p P
+	infos := make([]VariantInfo, 0, len(u.variants))
+	for _, v := range u.variants {
+		info := VariantInfo{Tag: string(v.tag), Optional: v.optional}
+		if v.field != nil {
+			info.Type = reflect.TypeOf(v.field(&p)).Elem().Elem()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// Has returns true if the given discriminator value has been registered
+func (u *Union[K, P]) Has(tag K) bool {
+	_, ok := u.index[tag]
+	return ok
+}
+
+// HasPayload returns true if the given discriminator value has been registered as a
+// variant that carries a payload
+func (u *Union[K, P]) HasPayload(tag K) bool {
+	i, ok := u.index[tag]
+	return ok && u.variants[i].field != nil
+}
+
+// Marshal encodes the first populated variant in p, in order of registration, or null
+// if no variant is populated
+func (u *Union[K, P]) Marshal(p P) ([]byte, error) {
+	for _, v := range u.variants {
+		if v.field == nil {
+			continue
+		}
+		field := reflect.ValueOf(v.field(&p)).Elem()
+		if !field.IsNil() {
+			return json.Marshal(field.Interface())
+		}
+	}
+	return json.Marshal(nil)
+}
+
+// Unmarshal decodes data into the variant of p that's identified by tag. In non-strict
+// mode, unregistered tags are ignored; in strict mode, they result in ErrUnknownType,
+// and a missing payload results in ErrMissingPayload unless the variant is Optional.
+func (u *Union[K, P]) Unmarshal(tag K, data json.RawMessage, p *P, strict bool) error {
+	i, ok := u.index[tag]
+	if !ok {
+		if strict {
+			return fmt.Errorf("%w: %s '%s'", ErrUnknownType, u.discriminator, tag)
+		}
+		return nil
+	}
+	v := u.variants[i]
+	if v.field == nil {
+		return nil
+	}
+	if err := DecodePayload(data, v.field(p), strict); err != nil {
+		if err == ErrMissingPayload && v.optional {
+			return nil
+		}
+		if err == ErrMissingPayload {
+			return fmt.Errorf("%w: %s '%s' requires %s", err, u.discriminator, tag, u.key)
+		}
+		return err
+	}
+	return nil
+}
+
+// Check verifies that the Union is consistent with the structure of P: every variant
+// must resolve to a nil-able pointer field of P, and every such field must be
+// registered to at least one variant, so that no payload can be populated without
+// being handled
+func (u *Union[K, P]) Check() error {
+	var p P
+	value := reflect.ValueOf(&p).Elem()
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("union payload type %s is not a struct", value.Type())
+	}
+
+	fieldIndexByAddr := make(map[uintptr]int)
+	for i := 0; i < value.NumField(); i++ {
+		fieldIndexByAddr[value.Field(i).Addr().Pointer()] = i
+	}
+
+	var problems []string
+	handled := make(map[int]bool)
+	for _, v := range u.variants {
+		if v.field == nil {
+			continue
+		}
+		ptr := reflect.ValueOf(v.field(&p))
+		if ptr.Kind() != reflect.Pointer || ptr.Elem().Kind() != reflect.Pointer {
+			problems = append(problems, fmt.Sprintf("%s '%s' does not resolve to a pointer field", u.discriminator, v.tag))
+			continue
+		}
+		i, ok := fieldIndexByAddr[ptr.Pointer()]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s '%s' does not resolve to a field of %s", u.discriminator, v.tag, value.Type()))
+			continue
+		}
+		handled[i] = true
+	}
+	for i := 0; i < value.NumField(); i++ {
+		if !handled[i] {
+			problems = append(problems, fmt.Sprintf("%s.%s is not handled by any %s", value.Type(), value.Type().Field(i).Name, u.discriminator))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid union: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Validate verifies that p is consistent with the given discriminator value: the value
+// must be registered, the variant it identifies must be populated if that variant
+// carries a payload that's not Optional, and no other variant may be populated. If the
+// populated payload is Validatable, it's validated as well. Problems are reported
+// relative to the enclosing object, using the JSON keys supplied to NewUnion.
+func (u *Union[K, P]) Validate(tag K, p *P) error {
+	var v Validator
+	discriminatorPath := "/" + u.discriminator
+	payloadPath := "/" + u.key
+
+	i, ok := u.index[tag]
+	if !ok {
+		v.Check(false, discriminatorPath, fmt.Sprintf("unknown %s '%s'", u.discriminator, tag))
+		return v.Err()
+	}
+
+	var expected reflect.Value
+	optional := u.variants[i].optional
+	if field := u.variants[i].field; field != nil {
+		expected = reflect.ValueOf(field(p)).Elem()
+	}
+
+	value := reflect.ValueOf(p).Elem()
+	var populated []string
+	mismatched := false
+	for j := 0; j < value.NumField(); j++ {
+		field := value.Field(j)
+		if field.Kind() != reflect.Pointer || field.IsNil() {
+			continue
+		}
+		populated = append(populated, value.Type().Field(j).Name)
+		if !expected.IsValid() || field.Addr().Pointer() != expected.Addr().Pointer() {
+			mismatched = true
+		}
+	}
+
+	if len(populated) > 1 {
+		v.Check(false, payloadPath, fmt.Sprintf("must populate a single variant (got %s)", strings.Join(populated, ", ")))
+	} else if mismatched {
+		v.Check(false, payloadPath, fmt.Sprintf("%s does not match %s '%s'", populated[0], u.discriminator, tag))
+	}
+	if expected.IsValid() {
+		v.Check(optional || !expected.IsNil(), payloadPath, fmt.Sprintf("is required for %s '%s'", u.discriminator, tag))
+		if !expected.IsNil() {
+			if payload, ok := expected.Interface().(Validatable); ok {
+				v.Nested(payloadPath, payload.Validate())
+			}
+		}
+	}
+	return v.Err()
+}
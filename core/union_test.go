@@ -0,0 +1,143 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testKind string
+
+type testPayload struct {
+	Number *testPayloadNumber
+	Word   *testPayloadWord
+}
+
+type testPayloadNumber struct {
+	Value int `json:"value"`
+}
+
+type testPayloadWord struct {
+	Value string `json:"value"`
+}
+
+func newTestUnion() *Union[testKind, testPayload] {
+	return NewUnion[testKind, testPayload]("kind", "payload").
+		Empty("nothing").
+		Variant("number", func(p *testPayload) any { return &p.Number }).
+		Variant("word", func(p *testPayload) any { return &p.Word })
+}
+
+func Test_Union(t *testing.T) {
+	u := newTestUnion()
+	t.Run("marshal populated variant", func(t *testing.T) {
+		got, err := u.Marshal(testPayload{Word: &testPayloadWord{Value: "hello"}})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"value":"hello"}`, string(got))
+	})
+	t.Run("marshal empty payload", func(t *testing.T) {
+		got, err := u.Marshal(testPayload{})
+		assert.NoError(t, err)
+		assert.Equal(t, `null`, string(got))
+	})
+	t.Run("unmarshal variant", func(t *testing.T) {
+		var got testPayload
+		err := u.Unmarshal("number", json.RawMessage(`{"value":42}`), &got, false)
+		assert.NoError(t, err)
+		assert.Equal(t, testPayload{Number: &testPayloadNumber{Value: 42}}, got)
+	})
+	t.Run("unmarshal variant with no payload", func(t *testing.T) {
+		var got testPayload
+		err := u.Unmarshal("nothing", nil, &got, true)
+		assert.NoError(t, err)
+		assert.Equal(t, testPayload{}, got)
+	})
+	t.Run("unknown variant is ignored in non-strict mode", func(t *testing.T) {
+		var got testPayload
+		err := u.Unmarshal("bogus", json.RawMessage(`{"value":42}`), &got, false)
+		assert.NoError(t, err)
+		assert.Equal(t, testPayload{}, got)
+	})
+	t.Run("unknown variant is rejected in strict mode", func(t *testing.T) {
+		var got testPayload
+		err := u.Unmarshal("bogus", json.RawMessage(`{"value":42}`), &got, true)
+		assert.ErrorIs(t, err, ErrUnknownType)
+	})
+	t.Run("missing payload is rejected in strict mode", func(t *testing.T) {
+		var got testPayload
+		err := u.Unmarshal("word", json.RawMessage(`null`), &got, true)
+		assert.ErrorIs(t, err, ErrMissingPayload)
+	})
+	t.Run("mismatched payload is rejected in strict mode", func(t *testing.T) {
+		var got testPayload
+		err := u.Unmarshal("word", json.RawMessage(`{"value":42}`), &got, true)
+		assert.Error(t, err)
+	})
+	t.Run("unrecognized fields are ignored in strict mode", func(t *testing.T) {
+		var got testPayload
+		err := u.Unmarshal("word", json.RawMessage(`{"value":"hello","extra":1}`), &got, true)
+		assert.NoError(t, err)
+		assert.Equal(t, testPayload{Word: &testPayloadWord{Value: "hello"}}, got)
+	})
+	t.Run("has payload", func(t *testing.T) {
+		assert.True(t, u.Has("nothing"))
+		assert.False(t, u.HasPayload("nothing"))
+		assert.True(t, u.HasPayload("word"))
+		assert.False(t, u.Has("bogus"))
+	})
+}
+
+func Test_Union_Optional(t *testing.T) {
+	u := NewUnion[testKind, testPayload]("kind", "payload").
+		Variant("number", func(p *testPayload) any { return &p.Number }).
+		Optional("word", func(p *testPayload) any { return &p.Word })
+	t.Run("optional payload is decoded when present", func(t *testing.T) {
+		var got testPayload
+		err := u.Unmarshal("word", json.RawMessage(`{"value":"hello"}`), &got, true)
+		assert.NoError(t, err)
+		assert.Equal(t, testPayload{Word: &testPayloadWord{Value: "hello"}}, got)
+	})
+	t.Run("missing optional payload is accepted", func(t *testing.T) {
+		for _, data := range []json.RawMessage{nil, json.RawMessage(`null`)} {
+			for _, strict := range []bool{false, true} {
+				var got testPayload
+				err := u.Unmarshal("word", data, &got, strict)
+				assert.NoError(t, err)
+				assert.Equal(t, testPayload{}, got)
+			}
+		}
+	})
+	t.Run("missing optional payload is valid", func(t *testing.T) {
+		assert.NoError(t, u.Validate("word", &testPayload{}))
+		assert.Error(t, u.Validate("number", &testPayload{}))
+	})
+	t.Run("optional payload is reported in variant info", func(t *testing.T) {
+		variants := u.Variants()
+		assert.False(t, variants[0].Optional)
+		assert.True(t, variants[1].Optional)
+	})
+}
+
+func Test_Union_Check(t *testing.T) {
+	t.Run("consistent union", func(t *testing.T) {
+		assert.NoError(t, newTestUnion().Check())
+	})
+	t.Run("field not handled by any variant", func(t *testing.T) {
+		u := NewUnion[testKind, testPayload]("kind", "payload").
+			Variant("number", func(p *testPayload) any { return &p.Number })
+		err := u.Check()
+		assert.ErrorContains(t, err, "core.testPayload.Word is not handled by any kind")
+	})
+	t.Run("variant that doesn't resolve to a field", func(t *testing.T) {
+		var elsewhere *testPayloadWord
+		u := newTestUnion().Variant("stray", func(p *testPayload) any { return &elsewhere })
+		err := u.Check()
+		assert.ErrorContains(t, err, "kind 'stray' does not resolve to a field of core.testPayload")
+	})
+	t.Run("duplicate registration panics", func(t *testing.T) {
+		assert.Panics(t, func() {
+			newTestUnion().Empty("nothing")
+		})
+	})
+}
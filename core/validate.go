@@ -0,0 +1,151 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Validatable is implemented by every schema type that can verify that its own values
+// are consistent and complete, e.g. before being published or after being received
+type Validatable interface {
+	Validate() error
+}
+
+// FieldError describes a problem with the value at a single location within a message,
+// identified by a JSON-pointer-style path, e.g. "/payload/num_bits"
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors aggregates all the problems that were found while validating a value
+type ValidationErrors []FieldError
+
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("validation failed: %s", strings.Join(messages, "; "))
+}
+
+// Validator accumulates problems found while validating a value, so that all problems
+// can be reported at once
+type Validator struct {
+	errs ValidationErrors
+}
+
+// Check records a problem with the given message at the given path if ok is false
+func (v *Validator) Check(ok bool, path string, message string) {
+	if !ok {
+		v.errs = append(v.errs, FieldError{Path: path, Message: message})
+	}
+}
+
+// Nested records the result of validating a value nested at the given path: if err
+// is a ValidationErrors, each of its problems is recorded relative to that path
+func (v *Validator) Nested(path string, err error) {
+	if err == nil {
+		return
+	}
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		for _, fieldErr := range errs {
+			v.errs = append(v.errs, FieldError{Path: path + fieldErr.Path, Message: fieldErr.Message})
+		}
+		return
+	}
+	v.errs = append(v.errs, FieldError{Path: path, Message: err.Error()})
+}
+
+// Err returns a ValidationErrors if any problems have been recorded, or nil otherwise
+func (v *Validator) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// Validate verifies that a Viewer identifies a Twitch user
+func (v Viewer) Validate() error {
+	var validator Validator
+	validator.Check(v.TwitchUserId != "", "/twitch_user_id", "is required")
+	validator.Check(v.TwitchDisplayName != "", "/twitch_display_name", "is required")
+	return validator.Err()
+}
+
+// Validate verifies that a State is internally consistent: a screening can only take
+// place within a broadcast, and every screening is of a tape
+func (s State) Validate() error {
+	var v Validator
+	v.Check(s.BroadcastId >= 0, "/broadcast_id", "must not be negative")
+	v.Check(s.TapeId >= 0, "/tape_id", "must not be negative")
+	if s.ScreeningId != uuid.Nil {
+		v.Check(s.BroadcastId != 0, "/broadcast_id", "is required when screening_id is set")
+		v.Check(s.TapeId != 0, "/tape_id", "is required when screening_id is set")
+	} else {
+		v.Check(s.TapeId == 0, "/tape_id", "must not be set without screening_id")
+	}
+	return v.Err()
+}
+
+// Validate verifies that a Header is either entirely empty (indicating a bare
+// message), or fully populated
+func (h Header) Validate() error {
+	if h == (Header{}) {
+		return nil
+	}
+	var v Validator
+	v.Check(h.MessageId != uuid.Nil, "/message_id", "is required")
+	v.Check(h.CorrelationId != uuid.Nil, "/correlation_id", "is required")
+	v.Check(h.CausationId == nil || *h.CausationId != h.MessageId, "/causation_id", "must not be the message's own ID")
+	v.Check(!h.ProducedAt.IsZero(), "/produced_at", "is required")
+	v.Check(h.Producer != "", "/producer", "is required")
+	v.Check(h.SchemaVersion > 0, "/schema_version", "must be a positive number")
+	return v.Err()
+}
+
+// Validate verifies an Envelope's header, along with its body if the body is
+// Validatable
+func (e Envelope[T]) Validate() error {
+	var v Validator
+	v.Nested("", e.Header.Validate())
+	if body, ok := any(e.Body).(Validatable); ok {
+		v.Nested("/body", body.Validate())
+	}
+	return v.Err()
+}
+
+// Validate verifies that a GoalType is one of the creator goal types recognized by
+// Twitch
+func (t GoalType) Validate() error {
+	switch t {
+	case GoalTypeFollow, GoalTypeSubscription, GoalTypeSubscriptionCount:
+		return nil
+	case GoalTypeNewSubscription, GoalTypeNewSubscriptionCount:
+		return nil
+	case GoalTypeNewBit, GoalTypeNewCheerer:
+		return nil
+	}
+	return fmt.Errorf("'%s' is not a valid goal type", t)
+}
+
+// Validate verifies that a PollChoice identifies the choice, with no negative vote
+// counts
+func (c PollChoice) Validate() error {
+	var v Validator
+	v.Check(c.Id != "", "/id", "is required")
+	v.Check(c.NumVotes >= 0, "/num_votes", "must not be negative")
+	v.Check(c.NumChannelPointsVotes >= 0, "/num_channel_points_votes", "must not be negative")
+	return v.Err()
+}
@@ -0,0 +1,194 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Validator(t *testing.T) {
+	var nested Validator
+	nested.Check(false, "/twitch_user_id", "is required")
+
+	var v Validator
+	v.Check(true, "/type", "is fine")
+	v.Check(false, "/state/tape_id", "must not be negative")
+	v.Nested("/viewer", nested.Err())
+	v.Nested("/color", errors.New("'plaid' is not a valid color"))
+	v.Nested("/ignored", nil)
+
+	err := v.Err()
+	assert.Equal(t, ValidationErrors{
+		{Path: "/state/tape_id", Message: "must not be negative"},
+		{Path: "/viewer/twitch_user_id", Message: "is required"},
+		{Path: "/color", Message: "'plaid' is not a valid color"},
+	}, err)
+	assert.EqualError(t, err, "validation failed: /state/tape_id: must not be negative; /viewer/twitch_user_id: is required; /color: 'plaid' is not a valid color")
+
+	var empty Validator
+	assert.NoError(t, empty.Err())
+}
+
+func Test_State_Validate(t *testing.T) {
+	screeningId := uuid.MustParse("96d1ca5c-7658-48c9-8193-9d1739854467")
+	tests := []struct {
+		name    string
+		state   State
+		wantErr ValidationErrors
+	}{
+		{
+			"no active broadcast",
+			State{},
+			nil,
+		},
+		{
+			"broadcast with no screening",
+			State{BroadcastId: 13},
+			nil,
+		},
+		{
+			"screening within broadcast",
+			State{BroadcastId: 13, ScreeningId: screeningId, TapeId: 124},
+			nil,
+		},
+		{
+			"screening without broadcast",
+			State{ScreeningId: screeningId, TapeId: 124},
+			ValidationErrors{{Path: "/broadcast_id", Message: "is required when screening_id is set"}},
+		},
+		{
+			"screening without tape",
+			State{BroadcastId: 13, ScreeningId: screeningId},
+			ValidationErrors{{Path: "/tape_id", Message: "is required when screening_id is set"}},
+		},
+		{
+			"tape without screening",
+			State{BroadcastId: 13, TapeId: 124},
+			ValidationErrors{{Path: "/tape_id", Message: "must not be set without screening_id"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.state.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func Test_Envelope_Validate(t *testing.T) {
+	t.Run("new envelope is valid", func(t *testing.T) {
+		envelope := NewEnvelope("hooks", 1, Viewer{TwitchUserId: "1234", TwitchDisplayName: "Cool_User"})
+		assert.NoError(t, envelope.Validate())
+	})
+	t.Run("bare envelope is valid", func(t *testing.T) {
+		envelope := Envelope[Viewer]{Body: Viewer{TwitchUserId: "1234", TwitchDisplayName: "Cool_User"}}
+		assert.NoError(t, envelope.Validate())
+	})
+	t.Run("message that claims to have caused itself", func(t *testing.T) {
+		envelope := NewEnvelope("hooks", 1, Viewer{TwitchUserId: "1234", TwitchDisplayName: "Cool_User"})
+		envelope.CausationId = &envelope.MessageId
+		assert.Equal(t, ValidationErrors{
+			{Path: "/causation_id", Message: "must not be the message's own ID"},
+		}, envelope.Validate())
+	})
+	t.Run("partial header and invalid body", func(t *testing.T) {
+		envelope := Envelope[Viewer]{
+			Header: Header{
+				MessageId:     uuid.MustParse("5bc71c8b-4d42-4b80-8a6b-7fd2bde0b1c2"),
+				CorrelationId: uuid.MustParse("5bc71c8b-4d42-4b80-8a6b-7fd2bde0b1c2"),
+				ProducedAt:    time.Date(1997, 9, 1, 12, 0, 0, 0, time.UTC),
+				SchemaVersion: 1,
+			},
+			Body: Viewer{TwitchUserId: "1234"},
+		}
+		assert.Equal(t, ValidationErrors{
+			{Path: "/producer", Message: "is required"},
+			{Path: "/body/twitch_display_name", Message: "is required"},
+		}, envelope.Validate())
+	})
+}
+
+func Test_Union_Validate(t *testing.T) {
+	u := newTestUnion()
+	tests := []struct {
+		name    string
+		kind    testKind
+		payload testPayload
+		wantErr ValidationErrors
+	}{
+		{
+			"valid variant",
+			"word",
+			testPayload{Word: &testPayloadWord{Value: "hello"}},
+			nil,
+		},
+		{
+			"valid variant with no payload",
+			"nothing",
+			testPayload{},
+			nil,
+		},
+		{
+			"unknown variant",
+			"bogus",
+			testPayload{},
+			ValidationErrors{{Path: "/kind", Message: "unknown kind 'bogus'"}},
+		},
+		{
+			"missing payload",
+			"word",
+			testPayload{},
+			ValidationErrors{{Path: "/payload", Message: "is required for kind 'word'"}},
+		},
+		{
+			"mismatched payload",
+			"word",
+			testPayload{Number: &testPayloadNumber{Value: 1}},
+			ValidationErrors{
+				{Path: "/payload", Message: "Number does not match kind 'word'"},
+				{Path: "/payload", Message: "is required for kind 'word'"},
+			},
+		},
+		{
+			"payload for variant that carries none",
+			"nothing",
+			testPayload{Number: &testPayloadNumber{Value: 1}},
+			ValidationErrors{{Path: "/payload", Message: "Number does not match kind 'nothing'"}},
+		},
+		{
+			"multiple populated variants",
+			"word",
+			testPayload{Number: &testPayloadNumber{Value: 1}, Word: &testPayloadWord{Value: "hello"}},
+			ValidationErrors{{Path: "/payload", Message: "must populate a single variant (got Number, Word)"}},
+		},
+		{
+			"invalid payload",
+			"number",
+			testPayload{Number: &testPayloadNumber{Value: -1}},
+			ValidationErrors{{Path: "/payload/value", Message: "must not be negative"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.Validate(tt.kind, &tt.payload)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func (p testPayloadNumber) Validate() error {
+	var v Validator
+	v.Check(p.Value >= 0, "/value", "must not be negative")
+	return v.Err()
+}
@@ -0,0 +1,116 @@
+package genreq
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/golden-vcr/schemas/core"
+)
+
+var (
+	ErrNoStyle   = errors.New("no recognized image style")
+	ErrNoSubject = errors.New("no subject")
+)
+
+// ParseError is returned by ParseCommand when a message can't be interpreted as a
+// generation request
+type ParseError struct {
+	// Message is the text that failed to parse
+	Message string
+	// Style is the image style requested by the message, or an empty string if the
+	// message didn't begin with a recognized style
+	Style ImageStyle
+	// Err explains why the message didn't match: ErrNoStyle, ErrNoColor, or ErrNoSubject
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	if e.Style == "" {
+		return fmt.Sprintf("failed to parse command '%s': %v", e.Message, e.Err)
+	}
+	return fmt.Sprintf("failed to parse %s command '%s': %v", e.Style, e.Message, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// commandRegexp matches a message that begins with the name of an image style,
+// capturing the style (group 1) and the remaining text (group 2)
+var commandRegexp = regexp.MustCompile(`(?is)^(ghost|friend)\b\s*(.*)$`)
+
+// ghostOfRegexp matches the optional "of" that follows "ghost", as in "ghost of a VCR"
+var ghostOfRegexp = regexp.MustCompile(`(?i)^of\b\s*`)
+
+// trimLeadingPunctuation removes any punctuation and whitespace that separates a style
+// name or color from the text that follows it, as in "ghost, of a cat"
+func trimLeadingPunctuation(s string) string {
+	return strings.TrimLeftFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(",.:;!?-", r)
+	})
+}
+
+// ParseCommand interprets the message that a viewer supplied with a cheer or a fun
+// point redemption as a request to generate an image, returning a Request on behalf of
+// the given viewer, in the given broadcast state. The message must begin with the name
+// of an image style, followed by that style's inputs:
+//
+//   - "ghost of <subject>", e.g. "ghost of a haunted VCR" (the "of" is optional)
+//   - "friend <color> <subject>", e.g. "friend red a tiny robot"
+//
+// Style names and colors are case-insensitive, and any punctuation that separates them
+// from the text that follows (as in "ghost, of a cat") is ignored. Messages from cheers
+// should have their cheermotes and emotes removed before parsing (see
+// etwitch.PayloadViewerCheered's CleanText method). If the message can't be parsed, the
+// returned error is a *ParseError.
+func ParseCommand(message string, viewer core.Viewer, state core.State) (*Request, error) {
+	message = strings.TrimSpace(message)
+	m := commandRegexp.FindStringSubmatch(message)
+	if m == nil {
+		return nil, &ParseError{Message: message, Err: ErrNoStyle}
+	}
+	style := ImageStyle(strings.ToLower(m[1]))
+	remainder := trimLeadingPunctuation(m[2])
+
+	var inputs ImageInputs
+	switch style {
+	case ImageStyleGhost:
+		subject := strings.TrimSpace(trimLeadingPunctuation(ghostOfRegexp.ReplaceAllString(remainder, "")))
+		if subject == "" {
+			return nil, &ParseError{Message: message, Style: style, Err: ErrNoSubject}
+		}
+		inputs.Ghost = &ImageInputsGhost{Subject: subject}
+	case ImageStyleFriend:
+		color, subject, err := MatchColor(remainder)
+		if err != nil {
+			return nil, &ParseError{Message: message, Style: style, Err: err}
+		}
+		// MatchColor will match on a prefix of a longer word (e.g. "red" in "redwood"),
+		// so require that the color not be immediately followed by a letter or digit
+		matched := strings.TrimRightFunc(remainder[:len(remainder)-len(subject)], unicode.IsSpace)
+		if next, _ := utf8.DecodeRuneInString(remainder[len(matched):]); unicode.IsLetter(next) || unicode.IsDigit(next) {
+			return nil, &ParseError{Message: message, Style: style, Err: ErrNoColor}
+		}
+		subject = strings.TrimSpace(trimLeadingPunctuation(subject))
+		if subject == "" {
+			return nil, &ParseError{Message: message, Style: style, Err: ErrNoSubject}
+		}
+		inputs.Friend = &ImageInputsFriend{Color: color, Subject: subject}
+	}
+
+	return &Request{
+		Type:   RequestTypeImage,
+		Viewer: viewer,
+		State:  state,
+		Payload: Payload{
+			Image: &PayloadImage{
+				Style:  style,
+				Inputs: inputs,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,212 @@
+package genreq
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseCommand(t *testing.T) {
+	viewer := core.Viewer{
+		TwitchUserId:      "90790024",
+		TwitchDisplayName: "wasabimilkshake",
+	}
+	state := core.State{
+		BroadcastId: 13,
+		ScreeningId: uuid.MustParse("96d1ca5c-7658-48c9-8193-9d1739854467"),
+		TapeId:      124,
+	}
+	tests := []struct {
+		name      string
+		message   string
+		wantErr   error
+		wantStyle ImageStyle
+		want      *PayloadImage
+	}{
+		{
+			"ghost image",
+			"ghost of a haunted VCR",
+			nil,
+			"",
+			&PayloadImage{
+				Style:  ImageStyleGhost,
+				Inputs: ImageInputs{Ghost: &ImageInputsGhost{Subject: "a haunted VCR"}},
+			},
+		},
+		{
+			"ghost image without 'of'",
+			"Ghost a haunted VCR",
+			nil,
+			"",
+			&PayloadImage{
+				Style:  ImageStyleGhost,
+				Inputs: ImageInputs{Ghost: &ImageInputsGhost{Subject: "a haunted VCR"}},
+			},
+		},
+		{
+			"ghost image with surrounding whitespace",
+			"  GHOST OF  an offering   ",
+			nil,
+			"",
+			&PayloadImage{
+				Style:  ImageStyleGhost,
+				Inputs: ImageInputs{Ghost: &ImageInputsGhost{Subject: "an offering"}},
+			},
+		},
+		{
+			"ghost image whose subject begins with 'of'",
+			"ghost offering",
+			nil,
+			"",
+			&PayloadImage{
+				Style:  ImageStyleGhost,
+				Inputs: ImageInputs{Ghost: &ImageInputsGhost{Subject: "offering"}},
+			},
+		},
+		{
+			"friend image",
+			"friend red a tiny robot",
+			nil,
+			"",
+			&PayloadImage{
+				Style: ImageStyleFriend,
+				Inputs: ImageInputs{Friend: &ImageInputsFriend{
+					Color:   ColorRed,
+					Subject: "a tiny robot",
+				}},
+			},
+		},
+		{
+			"friend image with compound color",
+			"Friend Orange/Red caterpillar in a top hat",
+			nil,
+			"",
+			&PayloadImage{
+				Style: ImageStyleFriend,
+				Inputs: ImageInputs{Friend: &ImageInputsFriend{
+					Color:   ColorRedOrange,
+					Subject: "caterpillar in a top hat",
+				}},
+			},
+		},
+		{
+			"ghost image with punctuation after style",
+			"ghost, of a cat",
+			nil,
+			"",
+			&PayloadImage{
+				Style:  ImageStyleGhost,
+				Inputs: ImageInputs{Ghost: &ImageInputsGhost{Subject: "a cat"}},
+			},
+		},
+		{
+			"ghost image with punctuation after 'of'",
+			"ghost of: a cat",
+			nil,
+			"",
+			&PayloadImage{
+				Style:  ImageStyleGhost,
+				Inputs: ImageInputs{Ghost: &ImageInputsGhost{Subject: "a cat"}},
+			},
+		},
+		{
+			"friend image delimited by tabs",
+			"friend\tred\ta robot",
+			nil,
+			"",
+			&PayloadImage{
+				Style: ImageStyleFriend,
+				Inputs: ImageInputs{Friend: &ImageInputsFriend{
+					Color:   ColorRed,
+					Subject: "a robot",
+				}},
+			},
+		},
+		{
+			"friend image with punctuation after color",
+			"friend: red, a robot",
+			nil,
+			"",
+			&PayloadImage{
+				Style: ImageStyleFriend,
+				Inputs: ImageInputs{Friend: &ImageInputsFriend{
+					Color:   ColorRed,
+					Subject: "a robot",
+				}},
+			},
+		},
+		{
+			"unrecognized style",
+			"goblin of a haunted VCR",
+			ErrNoStyle,
+			"",
+			nil,
+		},
+		{
+			"style name as prefix of another word",
+			"ghostly figure",
+			ErrNoStyle,
+			"",
+			nil,
+		},
+		{
+			"empty message",
+			"",
+			ErrNoStyle,
+			"",
+			nil,
+		},
+		{
+			"ghost with no subject",
+			"ghost of",
+			ErrNoSubject,
+			ImageStyleGhost,
+			nil,
+		},
+		{
+			"friend with no color",
+			"friend a tiny robot",
+			ErrNoColor,
+			ImageStyleFriend,
+			nil,
+		},
+		{
+			"friend with color as prefix of another word",
+			"friend redwood tree",
+			ErrNoColor,
+			ImageStyleFriend,
+			nil,
+		},
+		{
+			"friend with no subject",
+			"friend sky blue",
+			ErrNoSubject,
+			ImageStyleFriend,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCommand(tt.message, viewer, state)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				var parseErr *ParseError
+				assert.True(t, errors.As(err, &parseErr))
+				assert.Equal(t, tt.wantStyle, parseErr.Style)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &Request{
+					Type:    RequestTypeImage,
+					Viewer:  viewer,
+					State:   state,
+					Payload: Payload{Image: tt.want},
+				}, got)
+				assert.NoError(t, got.Validate())
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package genreq
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unwrap(t *testing.T) {
+	req := Request{
+		Type: RequestTypeImage,
+		Viewer: core.Viewer{
+			TwitchUserId:      "90790024",
+			TwitchDisplayName: "wasabimilkshake",
+		},
+		Payload: Payload{
+			Image: &PayloadImage{
+				Style: ImageStyleGhost,
+				Inputs: ImageInputs{
+					Ghost: &ImageInputsGhost{
+						Subject: "a seal",
+					},
+				},
+			},
+		},
+	}
+	t.Run("enveloped request", func(t *testing.T) {
+		data, err := json.Marshal(Wrap(req, "dispatch"))
+		assert.NoError(t, err)
+
+		got, err := Unwrap(data)
+		assert.NoError(t, err)
+		assert.Equal(t, "dispatch", got.Producer)
+		assert.Equal(t, SchemaVersion, got.SchemaVersion)
+		assert.Equal(t, req, got.Body)
+	})
+	t.Run("bare request", func(t *testing.T) {
+		data, err := json.Marshal(req)
+		assert.NoError(t, err)
+
+		got, err := Unwrap(data)
+		assert.NoError(t, err)
+		assert.True(t, got.IsBare())
+		assert.Equal(t, req, got.Body)
+	})
+}
+
+func Test_WrapChild(t *testing.T) {
+	parent := core.NewEnvelope("hooks", 1, struct{}{})
+	got := WrapChild(parent.Header, Request{Type: RequestTypeImage}, "dispatch")
+	assert.Equal(t, parent.CorrelationId, got.CorrelationId)
+	assert.Equal(t, &parent.MessageId, got.CausationId)
+	assert.Equal(t, "dispatch", got.Producer)
+	assert.Equal(t, SchemaVersion, got.SchemaVersion)
+}
+
+func Test_UnwrapStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{
+			"ghost image request",
+			`{"type":"image","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"state":{"broadcast_id":0,"screening_id":"00000000-0000-0000-0000-000000000000","tape_id":0},"payload":{"style":"ghost","inputs":{"subject":"a seal"}}}`,
+			nil,
+		},
+		{
+			"unknown request type",
+			`{"type":"song","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"state":{"broadcast_id":0,"screening_id":"00000000-0000-0000-0000-000000000000","tape_id":0},"payload":{}}`,
+			core.ErrUnknownType,
+		},
+		{
+			"unknown image style",
+			`{"type":"image","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"state":{"broadcast_id":0,"screening_id":"00000000-0000-0000-0000-000000000000","tape_id":0},"payload":{"style":"clown","inputs":{"subject":"a seal"}}}`,
+			core.ErrUnknownType,
+		},
+		{
+			"missing image inputs",
+			`{"type":"image","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"state":{"broadcast_id":0,"screening_id":"00000000-0000-0000-0000-000000000000","tape_id":0},"payload":{"style":"friend"}}`,
+			core.ErrMissingPayload,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnwrapStrict([]byte(tt.data))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
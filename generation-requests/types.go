@@ -28,7 +28,23 @@ type Payload struct {
 	Image *PayloadImage
 }
 
+// payloadUnion registers every RequestType against the Payload field that carries its
+// data
+var payloadUnion = core.NewUnion[RequestType, Payload]("type", "payload").
+	Variant(RequestTypeImage, func(p *Payload) any { return &p.Image })
+
 func (e *Request) UnmarshalJSON(data []byte) error {
+	return e.unmarshal(data, false)
+}
+
+// UnmarshalJSONStrict decodes a Request in strict mode, failing with
+// core.ErrUnknownType if the request type (or image style) is not recognized, or with
+// core.ErrMissingPayload if the payload is absent
+func (e *Request) UnmarshalJSONStrict(data []byte) error {
+	return e.unmarshal(data, true)
+}
+
+func (e *Request) unmarshal(data []byte, strict bool) error {
 	type fields struct {
 		Type    RequestType     `json:"type"`
 		Viewer  core.Viewer     `json:"viewer"`
@@ -36,23 +52,20 @@ func (e *Request) UnmarshalJSON(data []byte) error {
 		Payload json.RawMessage `json:"payload"`
 	}
 	var f fields
-	if err := json.Unmarshal(data, &f); err != nil {
+	if err := core.DecodeJSON(data, &f, strict); err != nil {
 		return err
 	}
 
 	e.Type = f.Type
 	e.Viewer = f.Viewer
 	e.State = f.State
-	switch f.Type {
-	case RequestTypeImage:
-		return json.Unmarshal(f.Payload, &e.Payload.Image)
-	}
-	return nil
+	return payloadUnion.Unmarshal(f.Type, f.Payload, &e.Payload, strict)
 }
 
 func (p Payload) MarshalJSON() ([]byte, error) {
-	if p.Image != nil {
-		return json.Marshal(p.Image)
-	}
-	return json.Marshal(nil)
+	return payloadUnion.Marshal(p)
+}
+
+func (Payload) Union() core.UnionInfo {
+	return payloadUnion
 }
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"unicode"
 )
 
 var ErrNoColor = errors.New("not a color")
@@ -45,6 +46,14 @@ var Colors = []Color{
 	ColorMagenta,
 }
 
+func (Color) EnumValues() []string {
+	values := make([]string, 0, len(Colors))
+	for _, color := range Colors {
+		values = append(values, string(color))
+	}
+	return values
+}
+
 func (c Color) GetComplement() Color {
 	switch c {
 	case ColorRed:
@@ -99,11 +108,7 @@ func MatchColor(s string) (Color, string, error) {
 	if !ok {
 		return ColorRed, "", ErrNoColor
 	}
-	remainderPos := len(m[0])
-	for remainderPos < len(s) && s[remainderPos] == ' ' {
-		remainderPos++
-	}
-	return color, s[remainderPos:], nil
+	return color, strings.TrimLeftFunc(s[len(m[0]):], unicode.IsSpace), nil
 }
 
 func resolveLookupKey(lhs string, rhs string) string {
@@ -150,9 +155,9 @@ func makeColorRegexp() *regexp.Regexp {
 
 	// Prepare a regex pattern that will match on:
 	// - group 1 (required): any slug value
-	// - group 2 (optional): any slug value, delimited with a space, slash, or hyphen
+	// - group 2 (optional): any slug value, delimited with whitespace, slash, or hyphen
 	slugsGroup := fmt.Sprintf("(%s)", strings.Join(slugs, "|"))
-	delimChars := "[-/ ]"
+	delimChars := `[-/\s]`
 	pattern := fmt.Sprintf("(?i)^%s(?:%s%s)?", slugsGroup, delimChars, slugsGroup)
 	return regexp.MustCompile(pattern)
 }
@@ -41,6 +41,17 @@ func Test_MatchColor(t *testing.T) {
 		_, _, err = MatchColor("green-orange")
 		assert.ErrorIs(t, err, ErrNoColor)
 	})
+	t.Run("any whitespace delimits colors and remainder", func(t *testing.T) {
+		color, remainder, err := MatchColor("red\ta robot")
+		assert.NoError(t, err)
+		assert.Equal(t, ColorRed, color)
+		assert.Equal(t, "a robot", remainder)
+
+		color, remainder, err = MatchColor("orange\tred \n caterpillar")
+		assert.NoError(t, err)
+		assert.Equal(t, ColorRedOrange, color)
+		assert.Equal(t, "caterpillar", remainder)
+	})
 	t.Run("all color constants match as valid colors", func(t *testing.T) {
 		for _, color := range Colors {
 			got, remainder, err := MatchColor(string(color))
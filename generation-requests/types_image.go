@@ -1,6 +1,10 @@
 package genreq
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/golden-vcr/schemas/core"
+)
 
 // PayloadImage describes a request to generate one or more images for an alert
 type PayloadImage struct {
@@ -23,34 +27,43 @@ type ImageInputs struct {
 	Friend *ImageInputsFriend
 }
 
+// imageInputsUnion registers every ImageStyle against the ImageInputs field that
+// carries its data
+var imageInputsUnion = core.NewUnion[ImageStyle, ImageInputs]("style", "inputs").
+	Variant(ImageStyleGhost, func(i *ImageInputs) any { return &i.Ghost }).
+	Variant(ImageStyleFriend, func(i *ImageInputs) any { return &i.Friend })
+
 func (e *PayloadImage) UnmarshalJSON(data []byte) error {
+	return e.unmarshal(data, false)
+}
+
+// UnmarshalJSONStrict decodes a PayloadImage in strict mode, failing with
+// core.ErrUnknownType if the image style is not recognized, or with
+// core.ErrMissingPayload if no inputs are present
+func (e *PayloadImage) UnmarshalJSONStrict(data []byte) error {
+	return e.unmarshal(data, true)
+}
+
+func (e *PayloadImage) unmarshal(data []byte, strict bool) error {
 	type fields struct {
 		Style  ImageStyle      `json:"style"`
 		Inputs json.RawMessage `json:"inputs"`
 	}
 	var f fields
-	if err := json.Unmarshal(data, &f); err != nil {
+	if err := core.DecodeJSON(data, &f, strict); err != nil {
 		return err
 	}
 
 	e.Style = f.Style
-	switch f.Style {
-	case ImageStyleGhost:
-		return json.Unmarshal(f.Inputs, &e.Inputs.Ghost)
-	case ImageStyleFriend:
-		return json.Unmarshal(f.Inputs, &e.Inputs.Friend)
-	}
-	return nil
+	return imageInputsUnion.Unmarshal(f.Style, f.Inputs, &e.Inputs, strict)
 }
 
 func (i ImageInputs) MarshalJSON() ([]byte, error) {
-	if i.Ghost != nil {
-		return json.Marshal(i.Ghost)
-	}
-	if i.Friend != nil {
-		return json.Marshal(i.Friend)
-	}
-	return json.Marshal(nil)
+	return imageInputsUnion.Marshal(i)
+}
+
+func (ImageInputs) Union() core.UnionInfo {
+	return imageInputsUnion
 }
 
 type ImageInputsGhost struct {
@@ -82,3 +82,8 @@ func Test_Request(t *testing.T) {
 		})
 	}
 }
+
+func Test_unions(t *testing.T) {
+	assert.NoError(t, payloadUnion.Check())
+	assert.NoError(t, imageInputsUnion.Check())
+}
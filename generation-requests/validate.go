@@ -0,0 +1,49 @@
+package genreq
+
+import (
+	"fmt"
+
+	"github.com/golden-vcr/schemas/core"
+)
+
+// Validate verifies that a Request is well-formed: its type must be recognized, it must
+// carry a valid payload of the corresponding type (and no other), and it must identify
+// the requesting viewer along with a consistent broadcast state
+func (r Request) Validate() error {
+	var v core.Validator
+	v.Nested("", payloadUnion.Validate(r.Type, &r.Payload))
+	v.Nested("/viewer", r.Viewer.Validate())
+	v.Nested("/state", r.State.Validate())
+	return v.Err()
+}
+
+// Validate verifies that a PayloadImage is well-formed: its style must be recognized,
+// and it must carry valid inputs for that style (and no other)
+func (p PayloadImage) Validate() error {
+	var v core.Validator
+	v.Nested("", imageInputsUnion.Validate(p.Style, &p.Inputs))
+	return v.Err()
+}
+
+func (i ImageInputsGhost) Validate() error {
+	var v core.Validator
+	v.Check(i.Subject != "", "/subject", "is required")
+	return v.Err()
+}
+
+func (i ImageInputsFriend) Validate() error {
+	var v core.Validator
+	v.Nested("/color", i.Color.Validate())
+	v.Check(i.Subject != "", "/subject", "is required")
+	return v.Err()
+}
+
+// Validate verifies that a Color is one of the canonical Color constants
+func (c Color) Validate() error {
+	for _, color := range Colors {
+		if c == color {
+			return nil
+		}
+	}
+	return fmt.Errorf("'%s' is not a valid color", c)
+}
@@ -0,0 +1,101 @@
+package genreq
+
+import (
+	"testing"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Request_Validate(t *testing.T) {
+	viewer := core.Viewer{
+		TwitchUserId:      "90790024",
+		TwitchDisplayName: "wasabimilkshake",
+	}
+	tests := []struct {
+		name    string
+		req     Request
+		wantErr core.ValidationErrors
+	}{
+		{
+			"ghost image request",
+			Request{
+				Type:   RequestTypeImage,
+				Viewer: viewer,
+				Payload: Payload{
+					Image: &PayloadImage{
+						Style:  ImageStyleGhost,
+						Inputs: ImageInputs{Ghost: &ImageInputsGhost{Subject: "a seal"}},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"ghost image request with empty subject",
+			Request{
+				Type:   RequestTypeImage,
+				Viewer: viewer,
+				Payload: Payload{
+					Image: &PayloadImage{
+						Style:  ImageStyleGhost,
+						Inputs: ImageInputs{Ghost: &ImageInputsGhost{}},
+					},
+				},
+			},
+			core.ValidationErrors{{Path: "/payload/inputs/subject", Message: "is required"}},
+		},
+		{
+			"friend image request with invalid color",
+			Request{
+				Type:   RequestTypeImage,
+				Viewer: viewer,
+				Payload: Payload{
+					Image: &PayloadImage{
+						Style:  ImageStyleFriend,
+						Inputs: ImageInputs{Friend: &ImageInputsFriend{Color: "plaid", Subject: "a seal"}},
+					},
+				},
+			},
+			core.ValidationErrors{{Path: "/payload/inputs/color", Message: "'plaid' is not a valid color"}},
+		},
+		{
+			"request with screening but no broadcast",
+			Request{
+				Type:   RequestTypeImage,
+				Viewer: viewer,
+				State: core.State{
+					ScreeningId: uuid.MustParse("96d1ca5c-7658-48c9-8193-9d1739854467"),
+					TapeId:      124,
+				},
+				Payload: Payload{
+					Image: &PayloadImage{
+						Style:  ImageStyleGhost,
+						Inputs: ImageInputs{Ghost: &ImageInputsGhost{Subject: "a seal"}},
+					},
+				},
+			},
+			core.ValidationErrors{{Path: "/state/broadcast_id", Message: "is required when screening_id is set"}},
+		},
+		{
+			"request with no viewer or payload",
+			Request{Type: RequestTypeImage},
+			core.ValidationErrors{
+				{Path: "/payload", Message: "is required for type 'image'"},
+				{Path: "/viewer/twitch_user_id", Message: "is required"},
+				{Path: "/viewer/twitch_display_name", Message: "is required"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.wantErr, err)
+			}
+		})
+	}
+}
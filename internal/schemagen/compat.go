@@ -0,0 +1,277 @@
+package schemagen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Compatibility classifies a change to a schema according to which services can
+// continue to exchange messages while they're upgraded one at a time:
+//
+//   - A fully-compatible change has no effect on existing producers or consumers.
+//   - A backward-compatible change (e.g. adding a union variant) permits new messages
+//     that existing consumers may not recognize: consumers must be upgraded first.
+//   - A forward-compatible change (e.g. adding a required field) forbids messages that
+//     existing producers may still send: producers must be upgraded first.
+//   - A breaking change (e.g. removing a field or a union variant, or changing the type
+//     of a value) removes or reinterprets data that existing services rely on, so
+//     whichever service is upgraded last will misinterpret the other's messages.
+type Compatibility string
+
+const (
+	CompatibilityFull     Compatibility = "fully-compatible"
+	CompatibilityBackward Compatibility = "backward-compatible"
+	CompatibilityForward  Compatibility = "forward-compatible"
+	CompatibilityBreaking Compatibility = "breaking"
+)
+
+// Change describes a single difference between two versions of a schema. Path
+// identifies the affected object type in the new schema, e.g. "PayloadToast", or one
+// of its fields, e.g. "PayloadToast.viewer".
+type Change struct {
+	Path          string
+	Description   string
+	Compatibility Compatibility
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s: %s", c.Compatibility, c.Path, c.Description)
+}
+
+// Compare returns every change that was made to the schema described by old in order
+// to produce the schema described by new. Object types are compared structurally,
+// starting from the root of each Model, so renaming a Go type is not considered a
+// change. If only one of the Models describes the message envelope (as built by
+// BuildEnvelope), only the message bodies are compared.
+func Compare(old *Model, new *Model) []Change {
+	c := &comparer{
+		old:     old,
+		new:     new,
+		visited: make(map[string]bool),
+	}
+	oldRoot, newRoot := old.Root, new.Root
+	oldBody, oldIsEnvelope := old.envelopeBody()
+	newBody, newIsEnvelope := new.envelopeBody()
+	if oldIsEnvelope && !newIsEnvelope {
+		oldRoot = oldBody
+	} else if newIsEnvelope && !oldIsEnvelope {
+		newRoot = newBody
+	}
+	c.definition(oldRoot, newRoot)
+	return c.changes
+}
+
+type comparer struct {
+	old     *Model
+	new     *Model
+	visited map[string]bool
+	changes []Change
+}
+
+func (c *comparer) add(path string, compatibility Compatibility, format string, args ...any) {
+	c.changes = append(c.changes, Change{
+		Path:          path,
+		Description:   fmt.Sprintf(format, args...),
+		Compatibility: compatibility,
+	})
+}
+
+func (c *comparer) definition(oldName string, newName string) {
+	key := oldName + "\x00" + newName
+	if c.visited[key] {
+		return
+	}
+	c.visited[key] = true
+
+	oldDef, newDef := c.old.Lookup(oldName), c.new.Lookup(newName)
+	if oldDef == nil || newDef == nil {
+		return
+	}
+	c.fields(newName, oldDef.Fields, newDef.Fields)
+	c.union(newName, oldDef.Union, newDef.Union)
+}
+
+func (c *comparer) fields(path string, oldFields []Field, newFields []Field) {
+	newByName := make(map[string]Field, len(newFields))
+	for _, field := range newFields {
+		newByName[field.Name] = field
+	}
+	oldByName := make(map[string]Field, len(oldFields))
+	for _, oldField := range oldFields {
+		oldByName[oldField.Name] = oldField
+		fieldPath := path + "." + oldField.Name
+		newField, ok := newByName[oldField.Name]
+		if !ok {
+			if oldField.Optional {
+				c.add(fieldPath, CompatibilityFull, "optional field removed")
+			} else {
+				c.add(fieldPath, CompatibilityBreaking, "required field removed")
+			}
+			continue
+		}
+		if oldField.Optional && !newField.Optional {
+			c.add(fieldPath, CompatibilityForward, "field is now required")
+		} else if !oldField.Optional && newField.Optional {
+			c.add(fieldPath, CompatibilityBackward, "field is now optional")
+		}
+		c.typeRef(fieldPath, oldField.Type, newField.Type)
+	}
+	for _, newField := range newFields {
+		if _, ok := oldByName[newField.Name]; ok {
+			continue
+		}
+		// Consumers ignore unrecognized fields, even when decoding strictly, so existing
+		// consumers are unaffected by a field that new producers may send
+		if newField.Optional {
+			c.add(path+"."+newField.Name, CompatibilityFull, "optional field added")
+		} else {
+			c.add(path+"."+newField.Name, CompatibilityForward, "required field added")
+		}
+	}
+}
+
+func (c *comparer) typeRef(path string, old TypeRef, new TypeRef) {
+	if old.Kind != new.Kind {
+		c.add(path, CompatibilityBreaking, "type changed from %s to %s", old, new)
+		return
+	}
+	if !old.Nullable && new.Nullable {
+		c.add(path, CompatibilityBackward, "value may now be null")
+	} else if old.Nullable && !new.Nullable {
+		c.add(path, CompatibilityForward, "value may no longer be null")
+	}
+
+	switch old.Kind {
+	case KindRef:
+		c.definition(old.Ref, new.Ref)
+	case KindArray, KindMap:
+		c.typeRef(path+"[]", *old.Elem, *new.Elem)
+	case KindString:
+		c.enum(path, "value", old.Enum, new.Enum)
+	}
+}
+
+// enum compares the sets of values that are permitted for a string, where an empty set
+// indicates that any value is permitted
+func (c *comparer) enum(path string, what string, old []string, new []string) {
+	if len(old) == 0 && len(new) == 0 {
+		return
+	}
+	if len(old) == 0 {
+		c.add(path, CompatibilityForward, "%s is now restricted to %s", what, quoteAll(new))
+		return
+	}
+	if len(new) == 0 {
+		c.add(path, CompatibilityBackward, "%s is no longer restricted", what)
+		return
+	}
+	for _, value := range difference(new, old) {
+		c.add(path, CompatibilityBackward, "%s '%s' added", what, value)
+	}
+	for _, value := range difference(old, new) {
+		c.add(path, CompatibilityBreaking, "%s '%s' removed", what, value)
+	}
+}
+
+func (c *comparer) union(path string, old *Union, new *Union) {
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		c.add(path, CompatibilityBreaking, "became a union discriminated by '%s'", new.Discriminator)
+		return
+	case new == nil:
+		c.add(path, CompatibilityBreaking, "is no longer a union discriminated by '%s'", old.Discriminator)
+		return
+	}
+	if old.Discriminator != new.Discriminator {
+		c.add(path, CompatibilityBreaking, "union discriminator renamed from '%s' to '%s'", old.Discriminator, new.Discriminator)
+		return
+	}
+	if old.Key != new.Key {
+		c.add(path, CompatibilityBreaking, "union payload renamed from '%s' to '%s'", old.Key, new.Key)
+		return
+	}
+	if old.KeyOptional && !new.KeyOptional {
+		c.add(path+"."+new.Key, CompatibilityForward, "field is now required")
+	} else if !old.KeyOptional && new.KeyOptional {
+		c.add(path+"."+new.Key, CompatibilityBackward, "field is now optional")
+	}
+
+	oldTags := make([]string, 0, len(old.Variants))
+	for _, v := range old.Variants {
+		oldTags = append(oldTags, v.Tag)
+	}
+	newTags := make([]string, 0, len(new.Variants))
+	for _, v := range new.Variants {
+		newTags = append(newTags, v.Tag)
+	}
+	c.enum(path+"."+new.Discriminator, new.Discriminator, oldTags, newTags)
+
+	newByTag := make(map[string]Variant, len(new.Variants))
+	for _, v := range new.Variants {
+		newByTag[v.Tag] = v
+	}
+	for _, oldVariant := range old.Variants {
+		newVariant, ok := newByTag[oldVariant.Tag]
+		if !ok {
+			continue
+		}
+		switch {
+		case oldVariant.Payload == "" && newVariant.Payload != "" && newVariant.PayloadOptional:
+			c.add(path+"."+new.Key, CompatibilityBackward, "%s '%s' now carries an optional payload", new.Discriminator, newVariant.Tag)
+		case oldVariant.Payload == "" && newVariant.Payload != "":
+			c.add(path+"."+new.Key, CompatibilityForward, "%s '%s' now requires a payload", new.Discriminator, newVariant.Tag)
+		case oldVariant.Payload != "" && newVariant.Payload == "":
+			c.add(path+"."+new.Key, CompatibilityBreaking, "%s '%s' no longer carries a payload", new.Discriminator, newVariant.Tag)
+		case oldVariant.Payload != "":
+			if oldVariant.PayloadOptional && !newVariant.PayloadOptional {
+				c.add(path+"."+new.Key, CompatibilityForward, "%s '%s' now requires a payload", new.Discriminator, newVariant.Tag)
+			} else if !oldVariant.PayloadOptional && newVariant.PayloadOptional {
+				c.add(path+"."+new.Key, CompatibilityBackward, "%s '%s' payload is now optional", new.Discriminator, newVariant.Tag)
+			}
+			c.definition(oldVariant.Payload, newVariant.Payload)
+		}
+	}
+}
+
+func (ref TypeRef) String() string {
+	var s string
+	switch ref.Kind {
+	case KindRef:
+		s = ref.Ref
+	case KindArray:
+		s = "array of " + ref.Elem.String()
+	case KindMap:
+		s = "map of " + ref.Elem.String()
+	default:
+		s = string(ref.Kind)
+	}
+	if ref.Nullable {
+		s = "nullable " + s
+	}
+	return s
+}
+
+// difference returns the values in a that are not in b, preserving their order
+func difference(a []string, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, value := range b {
+		inB[value] = true
+	}
+	var result []string
+	for _, value := range a {
+		if !inB[value] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, "'"+value+"'")
+	}
+	return strings.Join(quoted, ", ")
+}
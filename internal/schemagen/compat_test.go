@@ -0,0 +1,273 @@
+package schemagen
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Compare(t *testing.T) {
+	// message returns a Model describing a union of toasts, which can be modified by
+	// each test case
+	message := func(modify func(m *Model)) *Model {
+		m := &Model{
+			Root: "Message",
+			Definitions: []*Definition{
+				{
+					Name: "Message",
+					Fields: []Field{
+						{Name: "viewer", Type: TypeRef{Kind: KindRef, Ref: "Viewer"}},
+						{Name: "color", Type: TypeRef{Kind: KindString, Enum: []string{"red", "blue"}}},
+						{Name: "note", Type: TypeRef{Kind: KindString}, Optional: true},
+					},
+					Union: &Union{
+						Discriminator: "type",
+						Key:           "payload",
+						Variants: []Variant{
+							{Tag: "followed"},
+							{Tag: "cheered", Payload: "Cheer"},
+						},
+					},
+				},
+				{
+					Name: "Viewer",
+					Fields: []Field{
+						{Name: "twitch_user_id", Type: TypeRef{Kind: KindString}},
+					},
+				},
+				{
+					Name: "Cheer",
+					Fields: []Field{
+						{Name: "num_bits", Type: TypeRef{Kind: KindInteger}},
+					},
+				},
+			},
+		}
+		if modify != nil {
+			modify(m)
+		}
+		return m
+	}
+
+	tests := []struct {
+		name   string
+		modify func(m *Model)
+		want   []Change
+	}{
+		{
+			"no changes",
+			nil,
+			nil,
+		},
+		{
+			"renamed type",
+			func(m *Model) {
+				m.Definitions[2].Name = "PayloadCheered"
+				m.Definitions[0].Union.Variants[1].Payload = "PayloadCheered"
+			},
+			nil,
+		},
+		{
+			"added optional field",
+			func(m *Model) {
+				m.Definitions[1].Fields = append(m.Definitions[1].Fields, Field{Name: "color", Type: TypeRef{Kind: KindString}, Optional: true})
+			},
+			[]Change{
+				{"Viewer.color", "optional field added", CompatibilityFull},
+			},
+		},
+		{
+			"added required field",
+			func(m *Model) {
+				m.Definitions[2].Fields = append(m.Definitions[2].Fields, Field{Name: "message", Type: TypeRef{Kind: KindString}})
+			},
+			[]Change{
+				{"Cheer.message", "required field added", CompatibilityForward},
+			},
+		},
+		{
+			"renamed field",
+			func(m *Model) {
+				m.Definitions[2].Fields[0].Name = "bits"
+			},
+			[]Change{
+				{"Cheer.num_bits", "required field removed", CompatibilityBreaking},
+				{"Cheer.bits", "required field added", CompatibilityForward},
+			},
+		},
+		{
+			"removed optional field",
+			func(m *Model) {
+				m.Definitions[0].Fields = m.Definitions[0].Fields[:2]
+			},
+			[]Change{
+				{"Message.note", "optional field removed", CompatibilityFull},
+			},
+		},
+		{
+			"changed field type",
+			func(m *Model) {
+				m.Definitions[2].Fields[0].Type = TypeRef{Kind: KindString}
+			},
+			[]Change{
+				{"Cheer.num_bits", "type changed from integer to string", CompatibilityBreaking},
+			},
+		},
+		{
+			"made field nullable and optional",
+			func(m *Model) {
+				m.Definitions[0].Fields[0].Type.Nullable = true
+				m.Definitions[0].Fields[0].Optional = true
+			},
+			[]Change{
+				{"Message.viewer", "field is now optional", CompatibilityBackward},
+				{"Message.viewer", "value may now be null", CompatibilityBackward},
+			},
+		},
+		{
+			"changed enum values",
+			func(m *Model) {
+				m.Definitions[0].Fields[1].Type.Enum = []string{"red", "green"}
+			},
+			[]Change{
+				{"Message.color", "value 'green' added", CompatibilityBackward},
+				{"Message.color", "value 'blue' removed", CompatibilityBreaking},
+			},
+		},
+		{
+			"added variant",
+			func(m *Model) {
+				m.Definitions[0].Union.Variants = append(m.Definitions[0].Union.Variants, Variant{Tag: "raided"})
+			},
+			[]Change{
+				{"Message.type", "type 'raided' added", CompatibilityBackward},
+			},
+		},
+		{
+			"removed variant",
+			func(m *Model) {
+				m.Definitions[0].Union.Variants = m.Definitions[0].Union.Variants[:1]
+			},
+			[]Change{
+				{"Message.type", "type 'cheered' removed", CompatibilityBreaking},
+			},
+		},
+		{
+			"removed variant payload",
+			func(m *Model) {
+				m.Definitions[0].Union.Variants[1].Payload = ""
+			},
+			[]Change{
+				{"Message.payload", "type 'cheered' no longer carries a payload", CompatibilityBreaking},
+			},
+		},
+		{
+			"added optional variant payload",
+			func(m *Model) {
+				m.Definitions[0].Union.Variants[0].Payload = "Cheer"
+				m.Definitions[0].Union.Variants[0].PayloadOptional = true
+			},
+			[]Change{
+				{"Message.payload", "type 'followed' now carries an optional payload", CompatibilityBackward},
+			},
+		},
+		{
+			"made variant payload optional",
+			func(m *Model) {
+				m.Definitions[0].Union.Variants[1].PayloadOptional = true
+			},
+			[]Change{
+				{"Message.payload", "type 'cheered' payload is now optional", CompatibilityBackward},
+			},
+		},
+		{
+			"renamed discriminator",
+			func(m *Model) {
+				m.Definitions[0].Union.Discriminator = "kind"
+			},
+			[]Change{
+				{"Message", "union discriminator renamed from 'type' to 'kind'", CompatibilityBreaking},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compare(message(nil), message(tt.modify))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_Compare_optionalFieldAdded(t *testing.T) {
+	// An optional field added to a type is fully compatible only if neither version of
+	// a strict consumer rejects messages produced by the other version
+	type viewerV1 struct {
+		TwitchUserId string `json:"twitch_user_id"`
+	}
+	type viewerV2 struct {
+		TwitchUserId string `json:"twitch_user_id"`
+		Color        string `json:"color,omitempty"`
+	}
+	oldModel, err := Build(reflect.TypeOf(viewerV1{}))
+	assert.NoError(t, err)
+	newModel, err := Build(reflect.TypeOf(viewerV2{}))
+	assert.NoError(t, err)
+	oldModel.Definitions[0].Name = "Viewer"
+	newModel.Definitions[0].Name = "Viewer"
+	oldModel.Root = "Viewer"
+	newModel.Root = "Viewer"
+	assert.Equal(t, []Change{
+		{"Viewer.color", "optional field added", CompatibilityFull},
+	}, Compare(oldModel, newModel))
+
+	t.Run("old consumer accepts new messages", func(t *testing.T) {
+		var v viewerV1
+		err := core.DecodeJSON([]byte(`{"twitch_user_id":"1234","color":"red"}`), &v, true)
+		assert.NoError(t, err)
+		assert.Equal(t, viewerV1{TwitchUserId: "1234"}, v)
+	})
+	t.Run("new consumer accepts old messages", func(t *testing.T) {
+		var v viewerV2
+		err := core.DecodeJSON([]byte(`{"twitch_user_id":"1234"}`), &v, true)
+		assert.NoError(t, err)
+		assert.Equal(t, viewerV2{TwitchUserId: "1234"}, v)
+	})
+}
+
+func Test_Compare_envelope(t *testing.T) {
+	type viewer struct {
+		TwitchUserId string `json:"twitch_user_id"`
+	}
+	type viewerV2 struct {
+		TwitchUserId string `json:"twitch_user_id"`
+		Color        string `json:"color,omitempty"`
+	}
+	body, err := Build(reflect.TypeOf(viewer{}))
+	assert.NoError(t, err)
+	envelope, err := BuildEnvelope(reflect.TypeOf(viewer{}))
+	assert.NoError(t, err)
+	envelopeV2, err := BuildEnvelope(reflect.TypeOf(viewerV2{}))
+	assert.NoError(t, err)
+
+	t.Run("schema that predates envelopes is compared to the body", func(t *testing.T) {
+		assert.Nil(t, Compare(body, envelope))
+		assert.Nil(t, Compare(envelope, body))
+		assert.Equal(t, []Change{
+			{"viewerV2.color", "optional field added", CompatibilityFull},
+		}, Compare(body, envelopeV2))
+	})
+	t.Run("envelopes are compared in full", func(t *testing.T) {
+		assert.Equal(t, []Change{
+			{"viewerV2.color", "optional field added", CompatibilityFull},
+		}, Compare(envelope, envelopeV2))
+
+		modified, err := BuildEnvelope(reflect.TypeOf(viewer{}))
+		assert.NoError(t, err)
+		modified.Definitions[0].Fields = modified.Definitions[0].Fields[1:]
+		assert.Equal(t, []Change{
+			{"Envelope.message_id", "required field removed", CompatibilityBreaking},
+		}, Compare(envelope, modified))
+	})
+}
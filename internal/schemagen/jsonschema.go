@@ -0,0 +1,166 @@
+package schemagen
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// JSONSchemaDialect identifies the version of JSON Schema that RenderJSONSchema emits
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// RenderJSONSchema renders the given Model as a JSON Schema document, with the given
+// title, in which every Definition is declared under $defs. Each Union is expressed as
+// a oneOf, with one subschema per variant that pins the discriminator to a const value.
+func RenderJSONSchema(title string, m *Model) ([]byte, error) {
+	defs := object{}
+	for _, def := range m.Definitions {
+		defs = defs.with(def.Name, definitionSchema(def))
+	}
+	doc := object{}.
+		with("$schema", JSONSchemaDialect).
+		with("title", title).
+		with("$ref", refPath(m.Root)).
+		with("$defs", defs)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func definitionSchema(def *Definition) object {
+	properties := object{}
+	required := []string{}
+	if def.Union != nil {
+		tags := make([]string, 0, len(def.Union.Variants))
+		for _, v := range def.Union.Variants {
+			tags = append(tags, v.Tag)
+		}
+		properties = properties.with(def.Union.Discriminator, object{}.with("type", "string").with("enum", tags))
+		required = append(required, def.Union.Discriminator)
+	}
+	for _, field := range def.Fields {
+		properties = properties.with(field.Name, typeSchema(field.Type))
+		if !field.Optional {
+			required = append(required, field.Name)
+		}
+	}
+
+	schema := object{}.
+		with("type", "object").
+		with("properties", properties).
+		with("required", required)
+	if def.Union != nil {
+		variants := make([]object, 0, len(def.Union.Variants))
+		for _, v := range def.Union.Variants {
+			variants = append(variants, variantSchema(def.Union, v))
+		}
+		schema = schema.
+			with("oneOf", variants).
+			with("discriminator", object{}.with("propertyName", def.Union.Discriminator))
+	}
+	return schema
+}
+
+func variantSchema(u *Union, v Variant) object {
+	var payload object
+	required := []string{u.Discriminator}
+	if v.Payload == "" {
+		payload = object{}.with("type", "null")
+		if !u.KeyOptional {
+			required = append(required, u.Key)
+		}
+	} else if v.PayloadOptional {
+		payload = object{}.with("oneOf", []object{
+			object{}.with("$ref", refPath(v.Payload)),
+			object{}.with("type", "null"),
+		})
+		if !u.KeyOptional {
+			required = append(required, u.Key)
+		}
+	} else {
+		payload = object{}.with("$ref", refPath(v.Payload))
+		required = append(required, u.Key)
+	}
+	return object{}.
+		with("properties", object{}.
+			with(u.Discriminator, object{}.with("const", v.Tag)).
+			with(u.Key, payload)).
+		with("required", required)
+}
+
+func typeSchema(ref TypeRef) object {
+	var schema object
+	switch ref.Kind {
+	case KindRef:
+		schema = object{}.with("$ref", refPath(ref.Ref))
+		if ref.Nullable {
+			return object{}.with("oneOf", []object{schema, object{}.with("type", "null")})
+		}
+		return schema
+	case KindTimestamp:
+		schema = object{}.with("type", jsonType("string", ref.Nullable)).with("format", "date-time")
+	case KindUUID:
+		schema = object{}.with("type", jsonType("string", ref.Nullable)).with("format", "uuid")
+	case KindArray:
+		schema = object{}.with("type", jsonType("array", ref.Nullable)).with("items", typeSchema(*ref.Elem))
+	case KindMap:
+		schema = object{}.with("type", jsonType("object", ref.Nullable)).with("additionalProperties", typeSchema(*ref.Elem))
+	default:
+		schema = object{}.with("type", jsonType(string(ref.Kind), ref.Nullable))
+	}
+	if len(ref.Enum) > 0 {
+		schema = schema.with("enum", ref.Enum)
+	}
+	return schema
+}
+
+func jsonType(name string, nullable bool) any {
+	if nullable {
+		return []string{name, "null"}
+	}
+	return name
+}
+
+func refPath(name string) string {
+	return "#/$defs/" + name
+}
+
+// object is a JSON object whose keys are encoded in order of insertion
+type object []member
+
+type member struct {
+	key   string
+	value any
+}
+
+func (o object) with(key string, value any) object {
+	return append(o, member{key: key, value: value})
+}
+
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(m.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
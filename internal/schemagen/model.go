@@ -0,0 +1,366 @@
+// Package schemagen builds a language-neutral model of the types that make up a queue's
+// schema, by reflecting over the Go types along with the union and enum metadata
+// registered in core, and renders that model into machine-readable formats
+package schemagen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/google/uuid"
+)
+
+// Kind identifies how a value is represented in JSON
+type Kind string
+
+const (
+	KindString    Kind = "string"
+	KindInteger   Kind = "integer"
+	KindNumber    Kind = "number"
+	KindBoolean   Kind = "boolean"
+	KindTimestamp Kind = "timestamp"
+	KindUUID      Kind = "uuid"
+	KindArray     Kind = "array"
+	KindMap       Kind = "map"
+	KindRef       Kind = "ref"
+)
+
+// TypeRef describes the type of a single JSON value. For enum types, Name records the
+// name of the Go type that defines the set of valid values.
+type TypeRef struct {
+	Kind     Kind
+	Ref      string
+	Elem     *TypeRef
+	Nullable bool
+	Name     string
+	Enum     []string
+}
+
+// Field describes a single key of a JSON object
+type Field struct {
+	Name     string
+	Type     TypeRef
+	Optional bool
+}
+
+// Union describes a discriminated union embedded in an object: the object's
+// Discriminator field, whose values are of the Go type named by DiscriminatorType,
+// identifies which variant is encoded under its Key field
+type Union struct {
+	Discriminator     string
+	DiscriminatorType string
+	Key               string
+	KeyOptional       bool
+	Variants          []Variant
+}
+
+// Variant describes a single member of a Union: Payload names the Definition of the
+// payload encoded for that variant, or is empty if the variant carries no payload. If
+// PayloadOptional is true, the payload may be null.
+type Variant struct {
+	Tag             string
+	Payload         string
+	PayloadOptional bool
+}
+
+// Definition describes a named object type. If Union is non-nil, the Fields named by
+// its Discriminator and Key are omitted from Fields, since their types vary by variant.
+type Definition struct {
+	Name   string
+	Fields []Field
+	Union  *Union
+}
+
+// Model describes a schema rooted at a single object type, along with every named
+// type it references
+type Model struct {
+	Root        string
+	Definitions []*Definition
+}
+
+// Lookup returns the Definition with the given name
+func (m *Model) Lookup(name string) *Definition {
+	for _, def := range m.Definitions {
+		if def.Name == name {
+			return def
+		}
+	}
+	return nil
+}
+
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	uuidType        = reflect.TypeOf(uuid.UUID{})
+	enumType        = reflect.TypeOf((*core.Enum)(nil)).Elem()
+	taggedUnionType = reflect.TypeOf((*core.TaggedUnion)(nil)).Elem()
+)
+
+// Build reflects over the given struct type and returns a Model describing it
+func Build(root reflect.Type) (*Model, error) {
+	b := &builder{
+		model:   &Model{},
+		defined: make(map[string]reflect.Type),
+	}
+	ref, err := b.typeRef(root)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Kind != KindRef {
+		return nil, fmt.Errorf("root type %s is not a struct", root)
+	}
+	b.model.Root = ref.Ref
+	return b.model, nil
+}
+
+// envelopeName is the name of the Definition that BuildEnvelope adds to a Model
+const envelopeName = "Envelope"
+
+// envelopeBody returns the name of the Definition that describes the message body, if
+// the Model's root describes the message envelope
+func (m *Model) envelopeBody() (string, bool) {
+	if m.Root != envelopeName {
+		return "", false
+	}
+	def := m.Lookup(envelopeName)
+	if def == nil {
+		return "", false
+	}
+	for _, field := range def.Fields {
+		if field.Name == "body" && field.Type.Kind == KindRef {
+			return field.Type.Ref, true
+		}
+	}
+	return "", false
+}
+
+// BuildEnvelope reflects over the given struct type and returns a Model describing a
+// message as it's published: a core.Envelope, with the fields of core.Header alongside
+// a body that's described by the given type
+func BuildEnvelope(body reflect.Type) (*Model, error) {
+	m, err := Build(body)
+	if err != nil {
+		return nil, err
+	}
+	if m.Lookup(envelopeName) != nil {
+		return nil, fmt.Errorf("type name %s is reserved for the message envelope", envelopeName)
+	}
+	b := &builder{
+		model:   m,
+		defined: make(map[string]reflect.Type),
+	}
+	fields, err := b.fields(reflect.TypeOf(core.Header{}))
+	if err != nil {
+		return nil, err
+	}
+	def := &Definition{
+		Name:   envelopeName,
+		Fields: append(fields, Field{Name: "body", Type: TypeRef{Kind: KindRef, Ref: m.Root}}),
+	}
+	m.Root = def.Name
+	m.Definitions = append([]*Definition{def}, m.Definitions...)
+	return m, nil
+}
+
+type builder struct {
+	model   *Model
+	defined map[string]reflect.Type
+}
+
+func (b *builder) typeRef(t reflect.Type) (TypeRef, error) {
+	switch t {
+	case timeType:
+		return TypeRef{Kind: KindTimestamp}, nil
+	case uuidType:
+		return TypeRef{Kind: KindUUID}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		ref, err := b.typeRef(t.Elem())
+		ref.Nullable = true
+		return ref, err
+	case reflect.String:
+		ref := TypeRef{Kind: KindString}
+		if t.Implements(enumType) {
+			ref.Name = t.Name()
+			ref.Enum = reflect.Zero(t).Interface().(core.Enum).EnumValues()
+		}
+		return ref, nil
+	case reflect.Bool:
+		return TypeRef{Kind: KindBoolean}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return TypeRef{Kind: KindInteger}, nil
+	case reflect.Float32, reflect.Float64:
+		return TypeRef{Kind: KindNumber}, nil
+	case reflect.Slice:
+		elem, err := b.typeRef(t.Elem())
+		if err != nil {
+			return TypeRef{}, err
+		}
+		return TypeRef{Kind: KindArray, Elem: &elem, Nullable: true}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return TypeRef{}, fmt.Errorf("map type %s does not have string keys", t)
+		}
+		elem, err := b.typeRef(t.Elem())
+		if err != nil {
+			return TypeRef{}, err
+		}
+		return TypeRef{Kind: KindMap, Elem: &elem, Nullable: true}, nil
+	case reflect.Struct:
+		name, err := b.define(t)
+		if err != nil {
+			return TypeRef{}, err
+		}
+		return TypeRef{Kind: KindRef, Ref: name}, nil
+	}
+	return TypeRef{}, fmt.Errorf("unsupported type %s", t)
+}
+
+func (b *builder) define(t reflect.Type) (string, error) {
+	name := t.Name()
+	if name == "" {
+		return "", fmt.Errorf("anonymous struct types are not supported")
+	}
+	if existing, ok := b.defined[name]; ok {
+		if existing != t {
+			return "", fmt.Errorf("type name %s is ambiguous: %s and %s", name, existing, t)
+		}
+		return name, nil
+	}
+	b.defined[name] = t
+
+	def := &Definition{Name: name}
+	b.model.Definitions = append(b.model.Definitions, def)
+
+	fields, err := b.fields(t)
+	if err != nil {
+		return "", err
+	}
+	def.Fields = fields
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() || !sf.Type.Implements(taggedUnionType) {
+			continue
+		}
+		union, err := b.union(t, def, sf)
+		if err != nil {
+			return "", fmt.Errorf("%s.%s: %w", name, sf.Name, err)
+		}
+		def.Union = union
+	}
+	return name, nil
+}
+
+func (b *builder) fields(t reflect.Type) ([]Field, error) {
+	var fields []Field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key, optional, ok := parseJSONTag(sf)
+		if !ok {
+			continue
+		}
+		if sf.Anonymous && sf.Tag.Get("json") == "" && sf.Type.Kind() == reflect.Struct {
+			embedded, err := b.fields(sf.Type)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, embedded...)
+			continue
+		}
+		if sf.Type.Implements(taggedUnionType) {
+			fields = append(fields, Field{Name: key, Optional: optional})
+			continue
+		}
+		ref, err := b.typeRef(sf.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), sf.Name, err)
+		}
+		fields = append(fields, Field{Name: key, Type: ref, Optional: optional})
+	}
+	return fields, nil
+}
+
+func (b *builder) union(parent reflect.Type, def *Definition, sf reflect.StructField) (*Union, error) {
+	t := sf.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	info := reflect.Zero(t).Interface().(core.TaggedUnion).Union()
+	key, optional, _ := parseJSONTag(sf)
+	if key != info.Key() {
+		return nil, fmt.Errorf("union payload is encoded as '%s', not '%s'", key, info.Key())
+	}
+
+	union := &Union{
+		Discriminator: info.Discriminator(),
+		Key:           info.Key(),
+		KeyOptional:   optional,
+	}
+	for _, v := range info.Variants() {
+		variant := Variant{Tag: v.Tag}
+		if v.Type != nil {
+			ref, err := b.typeRef(v.Type)
+			if err != nil {
+				return nil, err
+			}
+			variant.Payload = ref.Ref
+			variant.PayloadOptional = v.Optional
+		}
+		union.Variants = append(union.Variants, variant)
+	}
+
+	// The discriminator and payload fields are described by the union's variants, so
+	// remove them from the set of fields that are common to all variants
+	fields := make([]Field, 0, len(def.Fields))
+	foundDiscriminator := false
+	for _, field := range def.Fields {
+		if field.Name == union.Discriminator {
+			foundDiscriminator = true
+			continue
+		}
+		if field.Name == union.Key {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	if !foundDiscriminator {
+		return nil, fmt.Errorf("discriminator '%s' is not a field of %s", union.Discriminator, def.Name)
+	}
+	def.Fields = fields
+
+	for i := 0; i < parent.NumField(); i++ {
+		if key, _, _ := parseJSONTag(parent.Field(i)); key == union.Discriminator {
+			union.DiscriminatorType = parent.Field(i).Type.Name()
+		}
+	}
+	return union, nil
+}
+
+// parseJSONTag returns the JSON key used to encode the given field, whether that key
+// is omitted when empty, and whether the field is encoded at all
+func parseJSONTag(sf reflect.StructField) (string, bool, bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = sf.Name
+	}
+	optional := false
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "omitempty" {
+			optional = true
+		}
+	}
+	return name, optional, true
+}
@@ -0,0 +1,126 @@
+package schemagen
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type testKind string
+
+type testColor string
+
+func (testColor) EnumValues() []string {
+	return []string{"red", "blue"}
+}
+
+type testMessage struct {
+	Kind      testKind     `json:"kind"`
+	Id        uuid.UUID    `json:"id"`
+	Viewer    *core.Viewer `json:"viewer"`
+	Tags      []string     `json:"tags,omitempty"`
+	Payload   testPayload  `json:"payload"`
+	Internal  string       `json:"-"`
+	unexposed int
+}
+
+type testPayload struct {
+	Painted *testPayloadPainted
+}
+
+var testPayloadUnion = core.NewUnion[testKind, testPayload]("kind", "payload").
+	Empty("nothing").
+	Variant("painted", func(p *testPayload) any { return &p.Painted }).
+	Optional("repainted", func(p *testPayload) any { return &p.Painted })
+
+func (testPayload) Union() core.UnionInfo {
+	return testPayloadUnion
+}
+
+type testPayloadPainted struct {
+	Color     testColor `json:"color"`
+	PaintedAt time.Time `json:"painted_at"`
+}
+
+func Test_Build(t *testing.T) {
+	model, err := Build(reflect.TypeOf(testMessage{}))
+	assert.NoError(t, err)
+	assert.Equal(t, &Model{
+		Root: "testMessage",
+		Definitions: []*Definition{
+			{
+				Name: "testMessage",
+				Fields: []Field{
+					{Name: "id", Type: TypeRef{Kind: KindUUID}},
+					{Name: "viewer", Type: TypeRef{Kind: KindRef, Ref: "Viewer", Nullable: true}},
+					{Name: "tags", Type: TypeRef{Kind: KindArray, Elem: &TypeRef{Kind: KindString}, Nullable: true}, Optional: true},
+				},
+				Union: &Union{
+					Discriminator:     "kind",
+					DiscriminatorType: "testKind",
+					Key:               "payload",
+					Variants: []Variant{
+						{Tag: "nothing"},
+						{Tag: "painted", Payload: "testPayloadPainted"},
+						{Tag: "repainted", Payload: "testPayloadPainted", PayloadOptional: true},
+					},
+				},
+			},
+			{
+				Name: "Viewer",
+				Fields: []Field{
+					{Name: "twitch_user_id", Type: TypeRef{Kind: KindString}},
+					{Name: "twitch_display_name", Type: TypeRef{Kind: KindString}},
+				},
+			},
+			{
+				Name: "testPayloadPainted",
+				Fields: []Field{
+					{Name: "color", Type: TypeRef{Kind: KindString, Name: "testColor", Enum: []string{"red", "blue"}}},
+					{Name: "painted_at", Type: TypeRef{Kind: KindTimestamp}},
+				},
+			},
+		},
+	}, model)
+}
+
+func Test_Build_errors(t *testing.T) {
+	type unsupported struct {
+		Callback func() `json:"callback"`
+	}
+	_, err := Build(reflect.TypeOf(unsupported{}))
+	assert.ErrorContains(t, err, "unsupported type func()")
+
+	_, err = Build(reflect.TypeOf(""))
+	assert.ErrorContains(t, err, "is not a struct")
+}
+
+func Test_BuildEnvelope(t *testing.T) {
+	model, err := BuildEnvelope(reflect.TypeOf(testMessage{}))
+	assert.NoError(t, err)
+	assert.Equal(t, "Envelope", model.Root)
+	assert.Len(t, model.Definitions, 4)
+	assert.Equal(t, &Definition{
+		Name: "Envelope",
+		Fields: []Field{
+			{Name: "message_id", Type: TypeRef{Kind: KindUUID}},
+			{Name: "correlation_id", Type: TypeRef{Kind: KindUUID}},
+			{Name: "causation_id", Type: TypeRef{Kind: KindUUID, Nullable: true}, Optional: true},
+			{Name: "produced_at", Type: TypeRef{Kind: KindTimestamp}},
+			{Name: "producer", Type: TypeRef{Kind: KindString}},
+			{Name: "schema_version", Type: TypeRef{Kind: KindInteger}},
+			{Name: "body", Type: TypeRef{Kind: KindRef, Ref: "testMessage"}},
+		},
+	}, model.Definitions[0])
+	assert.NotNil(t, model.Lookup("testMessage"))
+
+	type Envelope struct {
+		Value string `json:"value"`
+	}
+	_, err = BuildEnvelope(reflect.TypeOf(Envelope{}))
+	assert.ErrorContains(t, err, "type name Envelope is reserved")
+}
@@ -0,0 +1,234 @@
+package schemagen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ParseJSONSchema parses a JSON Schema document that was produced by RenderJSONSchema,
+// returning the Model it describes. Go-specific details that aren't represented in the
+// schema (i.e. the names of enum and discriminator types) are left empty.
+func ParseJSONSchema(data []byte) (*Model, error) {
+	var doc struct {
+		Ref  string `json:"$ref"`
+		Defs object `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON Schema: %w", err)
+	}
+	root, err := parseRefPath(doc.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Model{Root: root}
+	for _, member := range doc.Defs {
+		raw, ok := member.value.(json.RawMessage)
+		if !ok {
+			return nil, fmt.Errorf("definition %s is not valid JSON", member.key)
+		}
+		def, err := parseDefinition(member.key, raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", member.key, err)
+		}
+		m.Definitions = append(m.Definitions, def)
+	}
+	if m.Lookup(root) == nil {
+		return nil, fmt.Errorf("root type %s is not defined", root)
+	}
+	return m, nil
+}
+
+type schemaNode struct {
+	Ref                  string          `json:"$ref"`
+	Type                 json.RawMessage `json:"type"`
+	Format               string          `json:"format"`
+	Enum                 []string        `json:"enum"`
+	Const                string          `json:"const"`
+	Items                *schemaNode     `json:"items"`
+	AdditionalProperties *schemaNode     `json:"additionalProperties"`
+	Properties           object          `json:"properties"`
+	Required             []string        `json:"required"`
+	OneOf                []schemaNode    `json:"oneOf"`
+	Discriminator        *struct {
+		PropertyName string `json:"propertyName"`
+	} `json:"discriminator"`
+}
+
+func parseDefinition(name string, data json.RawMessage) (*Definition, error) {
+	var node schemaNode
+	if err := json.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	required := make(map[string]bool)
+	for _, key := range node.Required {
+		required[key] = true
+	}
+
+	def := &Definition{Name: name}
+	discriminator := ""
+	if node.Discriminator != nil {
+		discriminator = node.Discriminator.PropertyName
+	}
+	for _, member := range node.Properties {
+		if member.key == discriminator {
+			continue
+		}
+		var prop schemaNode
+		if err := json.Unmarshal(member.value.(json.RawMessage), &prop); err != nil {
+			return nil, err
+		}
+		ref, err := parseTypeRef(prop)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", member.key, err)
+		}
+		def.Fields = append(def.Fields, Field{Name: member.key, Type: ref, Optional: !required[member.key]})
+	}
+
+	if discriminator != "" {
+		union, err := parseUnion(discriminator, node.OneOf)
+		if err != nil {
+			return nil, err
+		}
+		def.Union = union
+	}
+	return def, nil
+}
+
+func parseUnion(discriminator string, variants []schemaNode) (*Union, error) {
+	union := &Union{Discriminator: discriminator}
+	for _, node := range variants {
+		required := make(map[string]bool)
+		for _, key := range node.Required {
+			required[key] = true
+		}
+		variant := Variant{}
+		for _, member := range node.Properties {
+			var prop schemaNode
+			if err := json.Unmarshal(member.value.(json.RawMessage), &prop); err != nil {
+				return nil, err
+			}
+			if member.key == discriminator {
+				variant.Tag = prop.Const
+				continue
+			}
+			if union.Key != "" && union.Key != member.key {
+				return nil, fmt.Errorf("union payload is encoded as both '%s' and '%s'", union.Key, member.key)
+			}
+			union.Key = member.key
+			if len(prop.OneOf) == 2 && prop.OneOf[0].Ref != "" && string(prop.OneOf[1].Type) == `"null"` {
+				payload, err := parseRefPath(prop.OneOf[0].Ref)
+				if err != nil {
+					return nil, err
+				}
+				variant.Payload = payload
+				variant.PayloadOptional = true
+				if !required[member.key] {
+					union.KeyOptional = true
+				}
+			} else if prop.Ref != "" {
+				payload, err := parseRefPath(prop.Ref)
+				if err != nil {
+					return nil, err
+				}
+				variant.Payload = payload
+			} else if !required[member.key] {
+				union.KeyOptional = true
+			}
+		}
+		if variant.Tag == "" {
+			return nil, fmt.Errorf("union variant does not specify a const %s", discriminator)
+		}
+		union.Variants = append(union.Variants, variant)
+	}
+	return union, nil
+}
+
+func parseTypeRef(node schemaNode) (TypeRef, error) {
+	if node.Ref != "" {
+		name, err := parseRefPath(node.Ref)
+		return TypeRef{Kind: KindRef, Ref: name}, err
+	}
+	if len(node.OneOf) == 2 && node.OneOf[0].Ref != "" && string(node.OneOf[1].Type) == `"null"` {
+		ref, err := parseTypeRef(node.OneOf[0])
+		ref.Nullable = true
+		return ref, err
+	}
+
+	var name string
+	var ref TypeRef
+	if err := json.Unmarshal(node.Type, &name); err != nil {
+		var names []string
+		if err := json.Unmarshal(node.Type, &names); err != nil || len(names) != 2 || names[1] != "null" {
+			return TypeRef{}, fmt.Errorf("unsupported type %s", node.Type)
+		}
+		name = names[0]
+		ref.Nullable = true
+	}
+
+	switch name {
+	case "string":
+		switch node.Format {
+		case "date-time":
+			ref.Kind = KindTimestamp
+		case "uuid":
+			ref.Kind = KindUUID
+		default:
+			ref.Kind = KindString
+			ref.Enum = node.Enum
+		}
+	case "integer", "number", "boolean":
+		ref.Kind = Kind(name)
+	case "array", "object":
+		elemNode := node.Items
+		ref.Kind = KindArray
+		if name == "object" {
+			elemNode = node.AdditionalProperties
+			ref.Kind = KindMap
+		}
+		if elemNode == nil {
+			return TypeRef{}, fmt.Errorf("%s type does not specify the type of its elements", name)
+		}
+		elem, err := parseTypeRef(*elemNode)
+		if err != nil {
+			return TypeRef{}, err
+		}
+		ref.Elem = &elem
+	default:
+		return TypeRef{}, fmt.Errorf("unsupported type %s", node.Type)
+	}
+	return ref, nil
+}
+
+func parseRefPath(ref string) (string, error) {
+	name, ok := strings.CutPrefix(ref, refPath(""))
+	if !ok || name == "" {
+		return "", fmt.Errorf("unsupported $ref '%s'", ref)
+	}
+	return name, nil
+}
+
+// UnmarshalJSON decodes a JSON object while preserving the order of its keys, storing
+// each value as a json.RawMessage
+func (o *object) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := decoder.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("expected a JSON object")
+	}
+	*o = nil
+	for decoder.More() {
+		tok, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		*o = o.with(tok.(string), value)
+	}
+	_, err := decoder.Token()
+	return err
+}
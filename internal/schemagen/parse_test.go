@@ -0,0 +1,58 @@
+package schemagen
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseJSONSchema(t *testing.T) {
+	want, err := Build(reflect.TypeOf(testMessage{}))
+	assert.NoError(t, err)
+	data, err := RenderJSONSchema("test", want)
+	assert.NoError(t, err)
+
+	// Go type names aren't represented in JSON Schema, so they can't be recovered
+	want.Definitions[0].Union.DiscriminatorType = ""
+	want.Definitions[2].Fields[0].Type.Name = ""
+
+	got, err := ParseJSONSchema(data)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func Test_ParseJSONSchema_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			"invalid JSON",
+			`{`,
+			"failed to parse JSON Schema: unexpected end of JSON input",
+		},
+		{
+			"unsupported root reference",
+			`{"$ref":"other.json","$defs":{}}`,
+			"unsupported $ref 'other.json'",
+		},
+		{
+			"undefined root",
+			`{"$ref":"#/$defs/Message","$defs":{}}`,
+			"root type Message is not defined",
+		},
+		{
+			"unsupported field type",
+			`{"$ref":"#/$defs/Message","$defs":{"Message":{"type":"object","properties":{"x":{"type":["string","integer"]}}}}}`,
+			`Message: x: unsupported type ["string","integer"]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseJSONSchema([]byte(tt.data))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package schemagen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RenderTypeScript renders the given Model as a TypeScript module. Every Definition is
+// declared as an exported interface, and every enum type is declared as a union of
+// string literals. A Definition with a Union is declared as a discriminated union of
+// one interface per variant, along with a type guard that narrows to each variant.
+func RenderTypeScript(m *Model) ([]byte, error) {
+	r := &tsRenderer{
+		enums:    make(map[string][]string),
+		declared: make(map[string]string),
+	}
+	for _, def := range m.Definitions {
+		if err := r.declare(def.Name, "definition"); err != nil {
+			return nil, err
+		}
+	}
+	for _, def := range m.Definitions {
+		if err := r.collectEnums(def); err != nil {
+			return nil, err
+		}
+		if def.Union != nil {
+			for _, v := range def.Union.Variants {
+				if err := r.declare(variantName(def, v), "variant of "+def.Name); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for _, name := range r.enumOrder {
+		r.enum(name, r.enums[name])
+	}
+	for _, def := range m.Definitions {
+		if def.Union != nil {
+			r.union(def)
+		} else {
+			r.iface(def.Name, nil, def.Fields, nil)
+		}
+	}
+	return bytes.TrimSuffix(r.buf.Bytes(), []byte("\n")), nil
+}
+
+type tsRenderer struct {
+	buf       bytes.Buffer
+	enums     map[string][]string
+	enumOrder []string
+	declared  map[string]string
+}
+
+// declare reserves a top-level TypeScript name, so that the generated module never
+// declares the same name twice
+func (r *tsRenderer) declare(name string, what string) error {
+	if existing, ok := r.declared[name]; ok {
+		return fmt.Errorf("TypeScript name %s is ambiguous: %s and %s", name, existing, what)
+	}
+	r.declared[name] = what
+	return nil
+}
+
+func (r *tsRenderer) collectEnums(def *Definition) error {
+	if def.Union != nil {
+		tags := make([]string, 0, len(def.Union.Variants))
+		for _, v := range def.Union.Variants {
+			tags = append(tags, v.Tag)
+		}
+		if err := r.addEnum(discriminatorTypeName(def), tags); err != nil {
+			return err
+		}
+	}
+	for _, field := range def.Fields {
+		ref := &field.Type
+		for ref.Elem != nil {
+			ref = ref.Elem
+		}
+		if ref.Name != "" && len(ref.Enum) > 0 {
+			if err := r.addEnum(ref.Name, ref.Enum); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *tsRenderer) addEnum(name string, values []string) error {
+	if existing, ok := r.enums[name]; ok {
+		if strings.Join(existing, "\x00") != strings.Join(values, "\x00") {
+			return fmt.Errorf("enum type %s is declared with conflicting values", name)
+		}
+		return nil
+	}
+	if err := r.declare(name, "enum"); err != nil {
+		return err
+	}
+	r.enums[name] = values
+	r.enumOrder = append(r.enumOrder, name)
+	return nil
+}
+
+func (r *tsRenderer) enum(name string, values []string) {
+	fmt.Fprintf(&r.buf, "export type %s =\n", name)
+	for i, value := range values {
+		terminator := ""
+		if i == len(values)-1 {
+			terminator = ";"
+		}
+		fmt.Fprintf(&r.buf, "  | %s%s\n", tsString(value), terminator)
+	}
+	r.buf.WriteString("\n")
+}
+
+// iface declares an interface with the given fields, along with any additional members,
+// which are rendered as-is
+func (r *tsRenderer) iface(name string, leading []string, fields []Field, trailing []string) {
+	fmt.Fprintf(&r.buf, "export interface %s {\n", name)
+	for _, member := range leading {
+		fmt.Fprintf(&r.buf, "  %s\n", member)
+	}
+	for _, field := range fields {
+		optional := ""
+		if field.Optional {
+			optional = "?"
+		}
+		fmt.Fprintf(&r.buf, "  %s%s: %s;\n", tsKey(field.Name), optional, tsType(field.Type))
+	}
+	for _, member := range trailing {
+		fmt.Fprintf(&r.buf, "  %s\n", member)
+	}
+	r.buf.WriteString("}\n\n")
+}
+
+func (r *tsRenderer) union(def *Definition) {
+	u := def.Union
+	fmt.Fprintf(&r.buf, "export type %s =\n", def.Name)
+	for i, v := range u.Variants {
+		terminator := ""
+		if i == len(u.Variants)-1 {
+			terminator = ";"
+		}
+		fmt.Fprintf(&r.buf, "  | %s%s\n", variantName(def, v), terminator)
+	}
+	r.buf.WriteString("\n")
+
+	for _, v := range u.Variants {
+		discriminator := fmt.Sprintf("%s: %s;", tsKey(u.Discriminator), tsString(v.Tag))
+		payload := fmt.Sprintf("%s: %s;", tsKey(u.Key), v.Payload)
+		if v.PayloadOptional {
+			payload = fmt.Sprintf("%s: %s | null;", tsKey(u.Key), v.Payload)
+		} else if v.Payload == "" {
+			optional := ""
+			if u.KeyOptional {
+				optional = "?"
+			}
+			payload = fmt.Sprintf("%s%s: null;", tsKey(u.Key), optional)
+		}
+		r.iface(variantName(def, v), []string{discriminator}, def.Fields, []string{payload})
+	}
+
+	for _, v := range u.Variants {
+		name := variantName(def, v)
+		fmt.Fprintf(&r.buf, "export function is%s(value: %s): value is %s {\n", name, def.Name, name)
+		fmt.Fprintf(&r.buf, "  return value%s === %s;\n", tsAccessor(u.Discriminator), tsString(v.Tag))
+		r.buf.WriteString("}\n\n")
+	}
+}
+
+// variantName returns the name of the interface that describes a single variant of a
+// union, e.g. PayloadToastGiftedSubs for the 'gifted-subs' variant of PayloadToast
+func variantName(def *Definition, v Variant) string {
+	var sb strings.Builder
+	sb.WriteString(def.Name)
+	for _, word := range strings.FieldsFunc(v.Tag, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		sb.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return sb.String()
+}
+
+// discriminatorTypeName returns the name of the enum type that enumerates the tags of a
+// Definition's union, preferring the name of the corresponding Go type
+func discriminatorTypeName(def *Definition) string {
+	if def.Union.DiscriminatorType != "" {
+		return def.Union.DiscriminatorType
+	}
+	return def.Name + "Type"
+}
+
+func tsType(ref TypeRef) string {
+	var s string
+	switch ref.Kind {
+	case KindRef:
+		s = ref.Ref
+	case KindString:
+		if ref.Name != "" && len(ref.Enum) > 0 {
+			s = ref.Name
+		} else if len(ref.Enum) > 0 {
+			literals := make([]string, 0, len(ref.Enum))
+			for _, value := range ref.Enum {
+				literals = append(literals, tsString(value))
+			}
+			s = strings.Join(literals, " | ")
+		} else {
+			s = "string"
+		}
+	case KindTimestamp, KindUUID:
+		s = "string"
+	case KindInteger, KindNumber:
+		s = "number"
+	case KindBoolean:
+		s = "boolean"
+	case KindArray:
+		elem := tsType(*ref.Elem)
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		s = elem + "[]"
+	case KindMap:
+		s = "Record<string, " + tsType(*ref.Elem) + ">"
+	default:
+		s = "unknown"
+	}
+	if ref.Nullable {
+		s += " | null"
+	}
+	return s
+}
+
+var tsIdentifierRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+func tsKey(name string) string {
+	if tsIdentifierRegex.MatchString(name) {
+		return name
+	}
+	return tsString(name)
+}
+
+func tsAccessor(name string) string {
+	if tsIdentifierRegex.MatchString(name) {
+		return "." + name
+	}
+	return "[" + tsString(name) + "]"
+}
+
+func tsString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return "'" + s + "'"
+}
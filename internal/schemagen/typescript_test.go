@@ -0,0 +1,94 @@
+package schemagen
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RenderTypeScript(t *testing.T) {
+	model, err := Build(reflect.TypeOf(testMessage{}))
+	assert.NoError(t, err)
+	got, err := RenderTypeScript(model)
+	assert.NoError(t, err)
+	assert.Equal(t, `export type testKind =
+  | 'nothing'
+  | 'painted'
+  | 'repainted';
+
+export type testColor =
+  | 'red'
+  | 'blue';
+
+export type testMessage =
+  | testMessageNothing
+  | testMessagePainted
+  | testMessageRepainted;
+
+export interface testMessageNothing {
+  kind: 'nothing';
+  id: string;
+  viewer: Viewer | null;
+  tags?: string[] | null;
+  payload: null;
+}
+
+export interface testMessagePainted {
+  kind: 'painted';
+  id: string;
+  viewer: Viewer | null;
+  tags?: string[] | null;
+  payload: testPayloadPainted;
+}
+
+export interface testMessageRepainted {
+  kind: 'repainted';
+  id: string;
+  viewer: Viewer | null;
+  tags?: string[] | null;
+  payload: testPayloadPainted | null;
+}
+
+export function istestMessageNothing(value: testMessage): value is testMessageNothing {
+  return value.kind === 'nothing';
+}
+
+export function istestMessagePainted(value: testMessage): value is testMessagePainted {
+  return value.kind === 'painted';
+}
+
+export function istestMessageRepainted(value: testMessage): value is testMessageRepainted {
+  return value.kind === 'repainted';
+}
+
+export interface Viewer {
+  twitch_user_id: string;
+  twitch_display_name: string;
+}
+
+export interface testPayloadPainted {
+  color: testColor;
+  painted_at: string;
+}
+`, string(got))
+}
+
+func Test_RenderTypeScript_errors(t *testing.T) {
+	model := &Model{
+		Root: "Message",
+		Definitions: []*Definition{
+			{
+				Name: "Message",
+				Union: &Union{
+					Discriminator: "type",
+					Key:           "payload",
+					Variants:      []Variant{{Tag: "extra"}},
+				},
+			},
+			{Name: "MessageExtra"},
+		},
+	}
+	_, err := RenderTypeScript(model)
+	assert.EqualError(t, err, "TypeScript name MessageExtra is ambiguous: definition and variant of Message")
+}
@@ -0,0 +1,128 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/golden-vcr/schemas/registry"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// AMQPBroker is a Broker backed by a RabbitMQ channel. Each queue name identifies a
+// durable fanout exchange of the same name: messages are published to that exchange and
+// are marked as persistent. Each subscriber consumes from a durable queue of its own,
+// named "<queue>.<subscriber>" (e.g. "twitch-events.dispatch"), which is declared and
+// bound to the exchange the first time the subscriber calls Receive, so that every
+// subscriber receives every message. Dead-letter exchanges for those queues should be
+// configured via RabbitMQ policies.
+type AMQPBroker struct {
+	ch          *amqp.Channel
+	consumerTag string
+
+	mu         sync.Mutex
+	exchanges  map[registry.Queue]bool
+	deliveries map[string]<-chan amqp.Delivery
+}
+
+// NewAMQPBroker initializes an AMQPBroker that uses the given channel. The consumer tag
+// identifies this service to RabbitMQ when it consumes from a queue, suffixed with the
+// name of that queue so that each consumer on the channel has a unique tag; if empty,
+// RabbitMQ will generate a unique tag for each consumer.
+func NewAMQPBroker(ch *amqp.Channel, consumerTag string) *AMQPBroker {
+	return &AMQPBroker{
+		ch:          ch,
+		consumerTag: consumerTag,
+		exchanges:   make(map[registry.Queue]bool),
+		deliveries:  make(map[string]<-chan amqp.Delivery),
+	}
+}
+
+func (b *AMQPBroker) Publish(ctx context.Context, queue registry.Queue, data []byte) error {
+	b.mu.Lock()
+	err := b.declareExchange(queue)
+	b.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return b.ch.PublishWithContext(ctx, string(queue), "", false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Body:         data,
+	})
+}
+
+func (b *AMQPBroker) Receive(ctx context.Context, queue registry.Queue, subscriber string) (RawDelivery, error) {
+	deliveries, err := b.consume(queue, subscriber)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case d, ok := <-deliveries:
+		if !ok {
+			return nil, fmt.Errorf("stopped consuming from %s: channel was closed", queue)
+		}
+		return &amqpDelivery{d: d}, nil
+	}
+}
+
+// consume returns the channel of deliveries from the subscriber's queue, declaring that
+// queue and beginning to consume from it if necessary
+func (b *AMQPBroker) consume(queue registry.Queue, subscriber string) (<-chan amqp.Delivery, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	name := string(queue) + "." + subscriber
+	if deliveries, ok := b.deliveries[name]; ok {
+		return deliveries, nil
+	}
+	if err := b.declareExchange(queue); err != nil {
+		return nil, err
+	}
+	if _, err := b.ch.QueueDeclare(name, true, false, false, false, nil); err != nil {
+		return nil, fmt.Errorf("failed to declare queue %s: %w", name, err)
+	}
+	if err := b.ch.QueueBind(name, "", string(queue), false, nil); err != nil {
+		return nil, fmt.Errorf("failed to bind queue %s to %s: %w", name, queue, err)
+	}
+	consumerTag := ""
+	if b.consumerTag != "" {
+		consumerTag = b.consumerTag + "." + name
+	}
+	deliveries, err := b.ch.Consume(name, consumerTag, false, false, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume from %s: %w", name, err)
+	}
+	b.deliveries[name] = deliveries
+	return deliveries, nil
+}
+
+// declareExchange declares the fanout exchange for the given queue, if it hasn't
+// already been declared; b.mu must be held
+func (b *AMQPBroker) declareExchange(queue registry.Queue) error {
+	if b.exchanges[queue] {
+		return nil
+	}
+	if err := b.ch.ExchangeDeclare(string(queue), amqp.ExchangeFanout, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare exchange %s: %w", queue, err)
+	}
+	b.exchanges[queue] = true
+	return nil
+}
+
+type amqpDelivery struct {
+	d amqp.Delivery
+}
+
+func (d *amqpDelivery) Body() []byte {
+	return d.d.Body
+}
+
+func (d *amqpDelivery) Ack() error {
+	return d.d.Ack(false)
+}
+
+func (d *amqpDelivery) Nack(requeue bool) error {
+	return d.d.Nack(false, requeue)
+}
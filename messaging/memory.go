@@ -0,0 +1,166 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golden-vcr/schemas/registry"
+)
+
+// MemoryBroker is a Broker that holds messages in memory, for use in tests. As with
+// AMQPBroker, each subscriber to a queue has a queue of its own, which receives every
+// message published after the subscriber's first call to Receive (or to Subscribe).
+// Each subscriber's queue delivers its messages in order of publication. Messages that
+// are nacked without being requeued are dead-lettered, and can be inspected with
+// DeadLettered.
+type MemoryBroker struct {
+	mu     sync.Mutex
+	queues map[registry.Queue]map[string]*memoryQueue
+}
+
+type memoryQueue struct {
+	ready        [][]byte
+	deadLettered [][]byte
+	unacked      int
+
+	// signal is closed (and replaced) whenever a message becomes ready
+	signal chan struct{}
+}
+
+// NewMemoryBroker initializes a MemoryBroker with no messages
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{
+		queues: make(map[registry.Queue]map[string]*memoryQueue),
+	}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, queue registry.Queue, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, q := range b.queues[queue] {
+		q.push(append([]byte(nil), data...), false)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Receive(ctx context.Context, queue registry.Queue, subscriber string) (RawDelivery, error) {
+	for {
+		b.mu.Lock()
+		q := b.queue(queue, subscriber)
+		if len(q.ready) > 0 {
+			data := q.ready[0]
+			q.ready = q.ready[1:]
+			q.unacked++
+			b.mu.Unlock()
+			return &memoryDelivery{broker: b, q: q, data: data}, nil
+		}
+		signal := q.signal
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-signal:
+		}
+	}
+}
+
+// Subscribe creates the subscriber's queue, if it doesn't already exist, so that it
+// receives every message that's published to the given queue from then on
+func (b *MemoryBroker) Subscribe(queue registry.Queue, subscriber string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.queue(queue, subscriber)
+}
+
+// Len returns the number of messages in the subscriber's queue that are ready to be
+// received
+func (b *MemoryBroker) Len(queue registry.Queue, subscriber string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.queue(queue, subscriber).ready)
+}
+
+// Unacked returns the number of messages from the subscriber's queue that have been
+// received but not yet acked or nacked
+func (b *MemoryBroker) Unacked(queue registry.Queue, subscriber string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.queue(queue, subscriber).unacked
+}
+
+// DeadLettered returns every message from the subscriber's queue that has been nacked
+// without being requeued, in the order in which they were nacked
+func (b *MemoryBroker) DeadLettered(queue registry.Queue, subscriber string) [][]byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([][]byte(nil), b.queue(queue, subscriber).deadLettered...)
+}
+
+// queue returns the state of the subscriber's queue, creating it if necessary; b.mu
+// must be held
+func (b *MemoryBroker) queue(queue registry.Queue, subscriber string) *memoryQueue {
+	subscribers, ok := b.queues[queue]
+	if !ok {
+		subscribers = make(map[string]*memoryQueue)
+		b.queues[queue] = subscribers
+	}
+	q, ok := subscribers[subscriber]
+	if !ok {
+		q = &memoryQueue{signal: make(chan struct{})}
+		subscribers[subscriber] = q
+	}
+	return q
+}
+
+// push makes a message ready to be received, either at the back of the queue or, if
+// it's being requeued, at the front; the broker's mu must be held
+func (q *memoryQueue) push(data []byte, requeue bool) {
+	if requeue {
+		q.ready = append([][]byte{data}, q.ready...)
+	} else {
+		q.ready = append(q.ready, data)
+	}
+	close(q.signal)
+	q.signal = make(chan struct{})
+}
+
+type memoryDelivery struct {
+	broker  *MemoryBroker
+	q       *memoryQueue
+	data    []byte
+	settled bool
+}
+
+func (d *memoryDelivery) Body() []byte {
+	return d.data
+}
+
+func (d *memoryDelivery) Ack() error {
+	return d.settle(func() {})
+}
+
+func (d *memoryDelivery) Nack(requeue bool) error {
+	return d.settle(func() {
+		if requeue {
+			d.q.push(d.data, true)
+		} else {
+			d.q.deadLettered = append(d.q.deadLettered, d.data)
+		}
+	})
+}
+
+func (d *memoryDelivery) settle(f func()) error {
+	d.broker.mu.Lock()
+	defer d.broker.mu.Unlock()
+	if d.settled {
+		return ErrAlreadySettled
+	}
+	d.settled = true
+	d.q.unacked--
+	f()
+	return nil
+}
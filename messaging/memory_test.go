@@ -0,0 +1,109 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryBroker(t *testing.T) {
+	const queue = "test-events"
+	const subscriber = "test"
+	ctx := context.Background()
+
+	t.Run("messages are delivered in order", func(t *testing.T) {
+		b := NewMemoryBroker()
+		b.Subscribe(queue, subscriber)
+		assert.NoError(t, b.Publish(ctx, queue, []byte("one")))
+		assert.NoError(t, b.Publish(ctx, queue, []byte("two")))
+		assert.Equal(t, 2, b.Len(queue, subscriber))
+
+		first, err := b.Receive(ctx, queue, subscriber)
+		assert.NoError(t, err)
+		assert.Equal(t, "one", string(first.Body()))
+		second, err := b.Receive(ctx, queue, subscriber)
+		assert.NoError(t, err)
+		assert.Equal(t, "two", string(second.Body()))
+		assert.Equal(t, 0, b.Len(queue, subscriber))
+		assert.Equal(t, 2, b.Unacked(queue, subscriber))
+
+		assert.NoError(t, first.Ack())
+		assert.NoError(t, second.Ack())
+		assert.Equal(t, 0, b.Unacked(queue, subscriber))
+		assert.ErrorIs(t, first.Ack(), ErrAlreadySettled)
+	})
+	t.Run("requeued messages are redelivered first", func(t *testing.T) {
+		b := NewMemoryBroker()
+		b.Subscribe(queue, subscriber)
+		assert.NoError(t, b.Publish(ctx, queue, []byte("one")))
+		assert.NoError(t, b.Publish(ctx, queue, []byte("two")))
+
+		d, err := b.Receive(ctx, queue, subscriber)
+		assert.NoError(t, err)
+		assert.NoError(t, d.Nack(true))
+		assert.ErrorIs(t, d.Nack(true), ErrAlreadySettled)
+
+		d, err = b.Receive(ctx, queue, subscriber)
+		assert.NoError(t, err)
+		assert.Equal(t, "one", string(d.Body()))
+	})
+	t.Run("rejected messages are dead-lettered", func(t *testing.T) {
+		b := NewMemoryBroker()
+		b.Subscribe(queue, subscriber)
+		assert.NoError(t, b.Publish(ctx, queue, []byte("one")))
+
+		d, err := b.Receive(ctx, queue, subscriber)
+		assert.NoError(t, err)
+		assert.NoError(t, d.Nack(false))
+		assert.Equal(t, 0, b.Len(queue, subscriber))
+		assert.Equal(t, 0, b.Unacked(queue, subscriber))
+		assert.Equal(t, [][]byte{[]byte("one")}, b.DeadLettered(queue, subscriber))
+	})
+	t.Run("every subscriber receives every message", func(t *testing.T) {
+		b := NewMemoryBroker()
+		assert.NoError(t, b.Publish(ctx, queue, []byte("zero")))
+		b.Subscribe(queue, "a")
+		b.Subscribe(queue, "b")
+		assert.NoError(t, b.Publish(ctx, queue, []byte("one")))
+		assert.NoError(t, b.Publish(ctx, queue, []byte("two")))
+		assert.Equal(t, 2, b.Len(queue, "a"))
+		assert.Equal(t, 2, b.Len(queue, "b"))
+
+		for _, subscriber := range []string{"a", "b"} {
+			for _, want := range []string{"one", "two"} {
+				d, err := b.Receive(ctx, queue, subscriber)
+				assert.NoError(t, err)
+				assert.Equal(t, want, string(d.Body()))
+				assert.NoError(t, d.Ack())
+			}
+		}
+		assert.Equal(t, 0, b.Len(queue, "a"))
+		assert.Equal(t, 0, b.Len(queue, "b"))
+	})
+	t.Run("receive blocks until a message is published", func(t *testing.T) {
+		b := NewMemoryBroker()
+		b.Subscribe(queue, subscriber)
+		received := make(chan string)
+		go func() {
+			d, err := b.Receive(ctx, queue, subscriber)
+			assert.NoError(t, err)
+			received <- string(d.Body())
+		}()
+		assert.NoError(t, b.Publish(ctx, queue, []byte("one")))
+		select {
+		case body := <-received:
+			assert.Equal(t, "one", body)
+		case <-time.After(time.Second):
+			t.Fatal("message was not received")
+		}
+	})
+	t.Run("receive is canceled with its context", func(t *testing.T) {
+		b := NewMemoryBroker()
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		_, err := b.Receive(ctx, queue, subscriber)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
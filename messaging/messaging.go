@@ -0,0 +1,190 @@
+// Package messaging provides typed producers and consumers for each of the queues
+// described by this module, so that services can publish and consume messages without
+// handling envelopes and serialization themselves. Producers and consumers exchange
+// messages through a Broker: AMQPBroker is backed by RabbitMQ, and MemoryBroker holds
+// messages in memory so that services can be tested without a live RabbitMQ server.
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ebroadcast "github.com/golden-vcr/schemas/broadcast-events"
+	"github.com/golden-vcr/schemas/core"
+	genreq "github.com/golden-vcr/schemas/generation-requests"
+	eonscreen "github.com/golden-vcr/schemas/onscreen-events"
+	"github.com/golden-vcr/schemas/registry"
+	etwitch "github.com/golden-vcr/schemas/twitch-events"
+)
+
+// ErrMalformedMessage is returned by Consumer.Recv when a message can't be decoded; the
+// message is rejected without being requeued, so that it can be dead-lettered
+var ErrMalformedMessage = errors.New("malformed message")
+
+// ErrAlreadySettled is returned when a delivery is acked or nacked more than once
+var ErrAlreadySettled = errors.New("delivery has already been acked or nacked")
+
+// Broker transports encoded messages to and from named queues. Every message that's
+// published to a queue is delivered to each of that queue's subscribers, so that (for
+// example) both dispatch and broadcasts can act on every twitch-event.
+type Broker interface {
+	// Publish delivers a message to every subscriber to the given queue
+	Publish(ctx context.Context, queue registry.Queue, data []byte) error
+	// Receive blocks until a message is available from the given queue for the named
+	// subscriber, then returns it. The message must be acked or nacked once it's been
+	// handled. Multiple instances of the same subscriber share its messages.
+	Receive(ctx context.Context, queue registry.Queue, subscriber string) (RawDelivery, error)
+}
+
+// RawDelivery is an encoded message that's been received from a Broker
+type RawDelivery interface {
+	// Body returns the encoded message
+	Body() []byte
+	// Ack indicates that the message has been handled, removing it from the queue
+	Ack() error
+	// Nack indicates that the message could not be handled: if requeue is true, the
+	// message will be redelivered; otherwise it will be dead-lettered
+	Nack(requeue bool) error
+}
+
+// Binding associates a queue with the schema of the messages that are produced to it
+type Binding[T any] struct {
+	Queue registry.Queue
+
+	wrap         func(body T, producer string) core.Envelope[T]
+	wrapChild    func(parent core.Header, body T, producer string) core.Envelope[T]
+	unwrap       func(data []byte) (*core.Envelope[T], error)
+	unwrapStrict func(data []byte) (*core.Envelope[T], error)
+}
+
+var (
+	TwitchEvents = Binding[etwitch.Event]{
+		registry.QueueTwitchEvents, etwitch.Wrap, etwitch.WrapChild, etwitch.Unwrap, etwitch.UnwrapStrict,
+	}
+	BroadcastEvents = Binding[ebroadcast.Event]{
+		registry.QueueBroadcastEvents, ebroadcast.Wrap, ebroadcast.WrapChild, ebroadcast.Unwrap, ebroadcast.UnwrapStrict,
+	}
+	OnscreenEvents = Binding[eonscreen.Event]{
+		registry.QueueOnscreenEvents, eonscreen.Wrap, eonscreen.WrapChild, eonscreen.Unwrap, eonscreen.UnwrapStrict,
+	}
+	GenerationRequests = Binding[genreq.Request]{
+		registry.QueueGenerationRequests, genreq.Wrap, genreq.WrapChild, genreq.Unwrap, genreq.UnwrapStrict,
+	}
+)
+
+// Producer publishes messages of type T to a single queue, in versioned envelopes
+type Producer[T any] interface {
+	// Send publishes body in a new envelope, returning the header of that envelope
+	Send(ctx context.Context, body T) (core.Header, error)
+	// SendChild publishes body in an envelope that records it as having been produced
+	// in response to the message identified by parent, returning the header of that
+	// envelope
+	SendChild(ctx context.Context, parent core.Header, body T) (core.Header, error)
+}
+
+// Consumer receives messages of type T from a single queue
+type Consumer[T any] interface {
+	// Recv blocks until a message is available, then returns it. If the message can't
+	// be decoded, it's nacked without being requeued, and ErrMalformedMessage is
+	// returned: the caller may log the error and call Recv again.
+	Recv(ctx context.Context) (*Delivery[T], error)
+}
+
+// Delivery is a decoded message that's been received by a Consumer: it must be acked
+// or nacked once it's been handled
+type Delivery[T any] struct {
+	Envelope *core.Envelope[T]
+	raw      RawDelivery
+}
+
+// Ack indicates that the message has been handled, removing it from the queue
+func (d *Delivery[T]) Ack() error {
+	return d.raw.Ack()
+}
+
+// Nack indicates that the message could not be handled: if requeue is true, the
+// message will be redelivered; otherwise it will be dead-lettered
+func (d *Delivery[T]) Nack(requeue bool) error {
+	return d.raw.Nack(requeue)
+}
+
+// NewProducer returns a Producer that publishes to the given queue via broker,
+// identifying itself in each message's header by the given service name. Every message
+// is validated before it's published.
+func NewProducer[T any](broker Broker, binding Binding[T], producer string) Producer[T] {
+	return &producerImpl[T]{
+		broker:   broker,
+		binding:  binding,
+		producer: producer,
+	}
+}
+
+type producerImpl[T any] struct {
+	broker   Broker
+	binding  Binding[T]
+	producer string
+}
+
+func (p *producerImpl[T]) Send(ctx context.Context, body T) (core.Header, error) {
+	return p.publish(ctx, p.binding.wrap(body, p.producer))
+}
+
+func (p *producerImpl[T]) SendChild(ctx context.Context, parent core.Header, body T) (core.Header, error) {
+	return p.publish(ctx, p.binding.wrapChild(parent, body, p.producer))
+}
+
+func (p *producerImpl[T]) publish(ctx context.Context, envelope core.Envelope[T]) (core.Header, error) {
+	if err := envelope.Validate(); err != nil {
+		return core.Header{}, fmt.Errorf("refusing to publish invalid message to %s: %w", p.binding.Queue, err)
+	}
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return core.Header{}, fmt.Errorf("failed to marshal message for %s: %w", p.binding.Queue, err)
+	}
+	if err := p.broker.Publish(ctx, p.binding.Queue, data); err != nil {
+		return core.Header{}, fmt.Errorf("failed to publish message to %s: %w", p.binding.Queue, err)
+	}
+	return envelope.Header, nil
+}
+
+// NewConsumer returns a Consumer that receives messages from the given queue via
+// broker, on behalf of the named subscriber (i.e. the name of the consuming service):
+// each subscriber receives every message that's published to the queue. If strict is
+// true, messages are decoded in strict mode, so that messages with an unknown type or
+// a missing payload are rejected as malformed.
+func NewConsumer[T any](broker Broker, binding Binding[T], subscriber string, strict bool) Consumer[T] {
+	unwrap := binding.unwrap
+	if strict {
+		unwrap = binding.unwrapStrict
+	}
+	return &consumerImpl[T]{
+		broker:     broker,
+		binding:    binding,
+		subscriber: subscriber,
+		unwrap:     unwrap,
+	}
+}
+
+type consumerImpl[T any] struct {
+	broker     Broker
+	binding    Binding[T]
+	subscriber string
+	unwrap     func(data []byte) (*core.Envelope[T], error)
+}
+
+func (c *consumerImpl[T]) Recv(ctx context.Context) (*Delivery[T], error) {
+	raw, err := c.broker.Receive(ctx, c.binding.Queue, c.subscriber)
+	if err != nil {
+		return nil, err
+	}
+	envelope, err := c.unwrap(raw.Body())
+	if err != nil {
+		if nackErr := raw.Nack(false); nackErr != nil {
+			return nil, fmt.Errorf("failed to reject malformed message from %s: %w", c.binding.Queue, nackErr)
+		}
+		return nil, fmt.Errorf("%w from %s: %v", ErrMalformedMessage, c.binding.Queue, err)
+	}
+	return &Delivery[T]{Envelope: envelope, raw: raw}, nil
+}
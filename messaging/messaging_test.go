@@ -0,0 +1,135 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golden-vcr/schemas/core"
+	genreq "github.com/golden-vcr/schemas/generation-requests"
+	eonscreen "github.com/golden-vcr/schemas/onscreen-events"
+	etwitch "github.com/golden-vcr/schemas/twitch-events"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_flow(t *testing.T) {
+	// Simulate the flow of a single redemption from hooks, through dispatch and dynamo,
+	// to alerts
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	broker := NewMemoryBroker()
+	viewer := core.Viewer{TwitchUserId: "90790024", TwitchDisplayName: "wasabimilkshake"}
+	broker.Subscribe(TwitchEvents.Queue, "dispatch")
+	broker.Subscribe(GenerationRequests.Queue, "dynamo")
+	broker.Subscribe(OnscreenEvents.Queue, "alerts")
+
+	// hooks: receives a channel point redemption from Twitch
+	hooks := NewProducer(broker, TwitchEvents, "hooks")
+	root, err := hooks.Send(ctx, etwitch.Event{
+		Type:   etwitch.EventTypeViewerRedeemedFunPoints,
+		Viewer: &viewer,
+		Payload: &etwitch.Payload{
+			ViewerRedeemedFunPoints: &etwitch.PayloadViewerRedeemedFunPoints{
+				NumPoints: 200,
+				Message:   "ghost of a seal",
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	// dispatch: requests an image in response to the redemption
+	dispatchConsumer := NewConsumer(broker, TwitchEvents, "dispatch", true)
+	dispatchProducer := NewProducer(broker, GenerationRequests, "dispatch")
+	redemption, err := dispatchConsumer.Recv(ctx)
+	assert.NoError(t, err)
+	_, err = dispatchProducer.SendChild(ctx, redemption.Envelope.Header, genreq.Request{
+		Type:   genreq.RequestTypeImage,
+		Viewer: *redemption.Envelope.Body.Viewer,
+		Payload: genreq.Payload{
+			Image: &genreq.PayloadImage{
+				Style: genreq.ImageStyleGhost,
+				Inputs: genreq.ImageInputs{
+					Ghost: &genreq.ImageInputsGhost{
+						Subject: redemption.Envelope.Body.Payload.ViewerRedeemedFunPoints.Message,
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, redemption.Ack())
+
+	// dynamo: generates the image and asks for it to be displayed
+	dynamoConsumer := NewConsumer(broker, GenerationRequests, "dynamo", true)
+	dynamoProducer := NewProducer(broker, OnscreenEvents, "dynamo")
+	request, err := dynamoConsumer.Recv(ctx)
+	assert.NoError(t, err)
+	_, err = dynamoProducer.SendChild(ctx, request.Envelope.Header, eonscreen.Event{
+		Type: eonscreen.EventTypeImage,
+		Payload: eonscreen.Payload{
+			Image: &eonscreen.PayloadImage{
+				Type:   eonscreen.ImageTypeGhost,
+				Viewer: request.Envelope.Body.Viewer,
+				Details: eonscreen.ImageDetails{
+					Ghost: &eonscreen.ImageDetailsGhost{
+						ImageUrl:    "https://my-cool-images.biz/seal.jpg",
+						Description: request.Envelope.Body.Payload.Image.Inputs.Ghost.Subject,
+					},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, request.Ack())
+
+	// alerts: displays the image
+	alertsConsumer := NewConsumer(broker, OnscreenEvents, "alerts", true)
+	alert, err := alertsConsumer.Recv(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, alert.Ack())
+
+	assert.Equal(t, "dynamo", alert.Envelope.Producer)
+	assert.Equal(t, root.CorrelationId, alert.Envelope.CorrelationId)
+	assert.Equal(t, &request.Envelope.MessageId, alert.Envelope.CausationId)
+	assert.Equal(t, "ghost of a seal", alert.Envelope.Body.Payload.Image.Details.Ghost.Description)
+	assert.Equal(t, 0, broker.Len(TwitchEvents.Queue, "dispatch")+broker.Len(GenerationRequests.Queue, "dynamo")+broker.Len(OnscreenEvents.Queue, "alerts"))
+	assert.Equal(t, 0, broker.Unacked(TwitchEvents.Queue, "dispatch")+broker.Unacked(GenerationRequests.Queue, "dynamo")+broker.Unacked(OnscreenEvents.Queue, "alerts"))
+}
+
+func Test_Producer_invalid(t *testing.T) {
+	broker := NewMemoryBroker()
+	broker.Subscribe(OnscreenEvents.Queue, "alerts")
+	producer := NewProducer(broker, OnscreenEvents, "alerts")
+	_, err := producer.Send(context.Background(), eonscreen.Event{Type: eonscreen.EventTypeStatus})
+	assert.ErrorContains(t, err, "/body/payload: is required for type 'status'")
+	assert.Equal(t, 0, broker.Len(OnscreenEvents.Queue, "alerts"))
+}
+
+func Test_Consumer_malformed(t *testing.T) {
+	ctx := context.Background()
+	broker := NewMemoryBroker()
+	broker.Subscribe(OnscreenEvents.Queue, "alerts")
+	assert.NoError(t, broker.Publish(ctx, OnscreenEvents.Queue, []byte(`{"type":"status","payload":{"current_tape_id":50}}`)))
+	assert.NoError(t, broker.Publish(ctx, OnscreenEvents.Queue, []byte(`{"type":"fireworks","payload":{}}`)))
+
+	t.Run("non-strict consumer accepts unknown types", func(t *testing.T) {
+		consumer := NewConsumer(broker, OnscreenEvents, "alerts", false)
+		for i := 0; i < 2; i++ {
+			delivery, err := consumer.Recv(ctx)
+			assert.NoError(t, err)
+			assert.NoError(t, delivery.Nack(true))
+		}
+	})
+	t.Run("strict consumer dead-letters unknown types", func(t *testing.T) {
+		consumer := NewConsumer(broker, OnscreenEvents, "alerts", true)
+		delivery, err := consumer.Recv(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, eonscreen.EventTypeStatus, delivery.Envelope.Body.Type)
+		assert.NoError(t, delivery.Ack())
+
+		_, err = consumer.Recv(ctx)
+		assert.ErrorIs(t, err, ErrMalformedMessage)
+		assert.ErrorContains(t, err, "fireworks")
+		assert.Equal(t, [][]byte{[]byte(`{"type":"fireworks","payload":{}}`)}, broker.DeadLettered(OnscreenEvents.Queue, "alerts"))
+	})
+}
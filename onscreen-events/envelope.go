@@ -0,0 +1,47 @@
+package eonscreen
+
+import (
+	"encoding/json"
+
+	"github.com/golden-vcr/schemas/core"
+)
+
+// SchemaVersion identifies the current revision of this schema: it's recorded in the
+// header of every enveloped message produced to the 'onscreen-events' queue
+const SchemaVersion = 1
+
+// Wrap places ev in a versioned envelope, identifying the producer as the name of the
+// service that's publishing it to the 'onscreen-events' queue
+func Wrap(ev Event, producer string) core.Envelope[Event] {
+	return core.NewEnvelope(producer, SchemaVersion, ev)
+}
+
+// WrapChild places ev in a versioned envelope, as with Wrap, recording that it was
+// produced in response to the message identified by parent: the resulting envelope
+// carries the parent's correlation ID, and its causation ID is the parent's message ID
+func WrapChild(parent core.Header, ev Event, producer string) core.Envelope[Event] {
+	return core.NewChildEnvelope(parent, producer, SchemaVersion, ev)
+}
+
+// Unwrap decodes a message consumed from the 'onscreen-events' queue: both enveloped
+// messages and bare Events (as published by producers that predate envelopes) are
+// accepted
+func Unwrap(data []byte) (*core.Envelope[Event], error) {
+	var envelope core.Envelope[Event]
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+	return &envelope, nil
+}
+
+// UnwrapStrict decodes a message consumed from the 'onscreen-events' queue, exactly as
+// with Unwrap, except that the Event is decoded in strict mode: messages with an
+// unknown type or a missing payload are rejected with core.ErrUnknownType or
+// core.ErrMissingPayload, so that consumers can dead-letter malformed messages
+func UnwrapStrict(data []byte) (*core.Envelope[Event], error) {
+	var envelope core.Envelope[Event]
+	if err := envelope.UnmarshalJSONStrict(data); err != nil {
+		return nil, err
+	}
+	return &envelope, nil
+}
@@ -0,0 +1,100 @@
+package eonscreen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unwrap(t *testing.T) {
+	ev := Event{
+		Type: EventTypeStatus,
+		Payload: Payload{
+			Status: &PayloadStatus{
+				CurrentTapeId: 50,
+			},
+		},
+	}
+	t.Run("enveloped event", func(t *testing.T) {
+		data, err := json.Marshal(Wrap(ev, "dynamo"))
+		assert.NoError(t, err)
+
+		got, err := Unwrap(data)
+		assert.NoError(t, err)
+		assert.Equal(t, "dynamo", got.Producer)
+		assert.Equal(t, SchemaVersion, got.SchemaVersion)
+		assert.Equal(t, ev, got.Body)
+	})
+	t.Run("bare event", func(t *testing.T) {
+		data, err := json.Marshal(ev)
+		assert.NoError(t, err)
+
+		got, err := Unwrap(data)
+		assert.NoError(t, err)
+		assert.True(t, got.IsBare())
+		assert.Equal(t, ev, got.Body)
+	})
+}
+
+func Test_UnwrapStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{
+			"toast with no data",
+			`{"type":"toast","payload":{"type":"followed","viewer":null}}`,
+			nil,
+		},
+		{
+			"image with details",
+			`{"type":"image","payload":{"type":"ghost","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"details":{"image_url":"https://my-cool-images.biz/seal.jpg","description":"a seal"}}}`,
+			nil,
+		},
+		{
+			"unknown event type",
+			`{"type":"confetti","payload":{}}`,
+			core.ErrUnknownType,
+		},
+		{
+			"missing payload",
+			`{"type":"status"}`,
+			core.ErrMissingPayload,
+		},
+		{
+			"unknown toast type",
+			`{"type":"toast","payload":{"type":"sneezed","viewer":null}}`,
+			core.ErrUnknownType,
+		},
+		{
+			"missing toast data",
+			`{"type":"toast","payload":{"type":"cheered","viewer":null}}`,
+			core.ErrMissingPayload,
+		},
+		{
+			"unknown image type",
+			`{"type":"image","payload":{"type":"animated","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"details":{}}}`,
+			core.ErrUnknownType,
+		},
+		{
+			"missing image details",
+			`{"type":"image","payload":{"type":"static","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"details":null}}`,
+			core.ErrMissingPayload,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnwrapStrict([]byte(tt.data))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package eonscreen
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/golden-vcr/schemas/core"
+)
 
 // Event represents an event that should be displayed onscreen during the stream
 type Event struct {
@@ -9,14 +13,16 @@ type Event struct {
 }
 
 // EventType indicates the type of event (e.g. change in stream status, toast
-// recognizing a viewer interaction, display of a generated image), all of which are
-// displayed differently in the onscreen graphics
+// recognizing a viewer interaction, display of a generated image, update to a goal
+// bar), all of which are displayed differently in the onscreen graphics
 type EventType string
 
 const (
 	EventTypeStatus EventType = "status"
 	EventTypeToast  EventType = "toast"
 	EventTypeImage  EventType = "image"
+	EventTypePoll   EventType = "poll"
+	EventTypeGoal   EventType = "goal"
 )
 
 // Payload carries event-type-specific data describing what needs to happen onscreen
@@ -24,39 +30,48 @@ type Payload struct {
 	Status *PayloadStatus
 	Toast  *PayloadToast
 	Image  *PayloadImage
+	Poll   *PayloadPoll
+	Goal   *PayloadGoal
 }
 
+// payloadUnion registers every EventType against the Payload field that carries its
+// data
+var payloadUnion = core.NewUnion[EventType, Payload]("type", "payload").
+	Variant(EventTypeStatus, func(p *Payload) any { return &p.Status }).
+	Variant(EventTypeToast, func(p *Payload) any { return &p.Toast }).
+	Variant(EventTypeImage, func(p *Payload) any { return &p.Image }).
+	Variant(EventTypePoll, func(p *Payload) any { return &p.Poll }).
+	Variant(EventTypeGoal, func(p *Payload) any { return &p.Goal })
+
 func (e *Event) UnmarshalJSON(data []byte) error {
+	return e.unmarshal(data, false)
+}
+
+// UnmarshalJSONStrict decodes an Event in strict mode, failing with core.ErrUnknownType
+// if the event type (or the type of its toast or image payload) is not recognized, or
+// with core.ErrMissingPayload if the payload is absent
+func (e *Event) UnmarshalJSONStrict(data []byte) error {
+	return e.unmarshal(data, true)
+}
+
+func (e *Event) unmarshal(data []byte, strict bool) error {
 	type fields struct {
 		Type    EventType       `json:"type"`
 		Payload json.RawMessage `json:"payload"`
 	}
 	var f fields
-	if err := json.Unmarshal(data, &f); err != nil {
+	if err := core.DecodeJSON(data, &f, strict); err != nil {
 		return err
 	}
 
 	e.Type = f.Type
-	switch f.Type {
-	case EventTypeStatus:
-		return json.Unmarshal(f.Payload, &e.Payload.Status)
-	case EventTypeToast:
-		return json.Unmarshal(f.Payload, &e.Payload.Toast)
-	case EventTypeImage:
-		return json.Unmarshal(f.Payload, &e.Payload.Image)
-	}
-	return nil
+	return payloadUnion.Unmarshal(f.Type, f.Payload, &e.Payload, strict)
 }
 
 func (p Payload) MarshalJSON() ([]byte, error) {
-	if p.Status != nil {
-		return json.Marshal(p.Status)
-	}
-	if p.Toast != nil {
-		return json.Marshal(p.Toast)
-	}
-	if p.Image != nil {
-		return json.Marshal(p.Image)
-	}
-	return json.Marshal(nil)
+	return payloadUnion.Marshal(p)
+}
+
+func (Payload) Union() core.UnionInfo {
+	return payloadUnion
 }
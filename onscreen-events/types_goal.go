@@ -0,0 +1,17 @@
+package eonscreen
+
+import "github.com/golden-vcr/schemas/core"
+
+// PayloadGoal describes the current state of a creator goal, so that the onscreen
+// graphics can display a persistent progress bar for as long as the goal is active: a
+// goal event is sent when the goal begins, each time progress is made toward it, and
+// once more when it ends, with IsEnded set
+type PayloadGoal struct {
+	GoalId        string        `json:"goal_id"`
+	Type          core.GoalType `json:"type"`
+	Description   string        `json:"description"`
+	CurrentAmount int           `json:"current_amount"`
+	TargetAmount  int           `json:"target_amount"`
+	IsEnded       bool          `json:"is_ended"`
+	IsAchieved    bool          `json:"is_achieved"`
+}
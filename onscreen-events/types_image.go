@@ -26,41 +26,46 @@ type ImageDetails struct {
 	Friend *ImageDetailsFriend
 }
 
+// imageDetailsUnion registers every ImageType against the ImageDetails field that
+// carries its data
+var imageDetailsUnion = core.NewUnion[ImageType, ImageDetails]("type", "details").
+	Variant(ImageTypeStatic, func(d *ImageDetails) any { return &d.Static }).
+	Variant(ImageTypeGhost, func(d *ImageDetails) any { return &d.Ghost }).
+	Variant(ImageTypeFriend, func(d *ImageDetails) any { return &d.Friend })
+
 func (p *PayloadImage) UnmarshalJSON(data []byte) error {
+	return p.unmarshal(data, false)
+}
+
+// UnmarshalJSONStrict decodes a PayloadImage in strict mode, failing with
+// core.ErrUnknownType if the image type is not recognized, or with
+// core.ErrMissingPayload if no details are present
+func (p *PayloadImage) UnmarshalJSONStrict(data []byte) error {
+	return p.unmarshal(data, true)
+}
+
+func (p *PayloadImage) unmarshal(data []byte, strict bool) error {
 	type fields struct {
 		Type    ImageType       `json:"type"`
 		Viewer  core.Viewer     `json:"viewer"`
 		Details json.RawMessage `json:"details"`
 	}
 	var f fields
-	if err := json.Unmarshal(data, &f); err != nil {
+	if err := core.DecodeJSON(data, &f, strict); err != nil {
 		return err
 	}
 
 	p.Type = f.Type
 	p.Viewer = f.Viewer
-	switch f.Type {
-	case ImageTypeStatic:
-		return json.Unmarshal(f.Details, &p.Details.Static)
-	case ImageTypeGhost:
-		return json.Unmarshal(f.Details, &p.Details.Ghost)
-	case ImageTypeFriend:
-		return json.Unmarshal(f.Details, &p.Details.Friend)
-	}
-	return nil
+	return imageDetailsUnion.Unmarshal(f.Type, f.Details, &p.Details, strict)
 }
 
 func (d ImageDetails) MarshalJSON() ([]byte, error) {
-	if d.Static != nil {
-		return json.Marshal(d.Static)
-	}
-	if d.Ghost != nil {
-		return json.Marshal(d.Ghost)
-	}
-	if d.Friend != nil {
-		return json.Marshal(d.Friend)
-	}
-	return json.Marshal(nil)
+	return imageDetailsUnion.Marshal(d)
+}
+
+func (ImageDetails) Union() core.UnionInfo {
+	return imageDetailsUnion
 }
 
 type ImageDetailsStatic struct {
@@ -0,0 +1,19 @@
+package eonscreen
+
+import (
+	"time"
+
+	"github.com/golden-vcr/schemas/core"
+)
+
+// PayloadPoll describes the current state of a poll, so that the onscreen graphics can
+// display a bar for each choice: a poll event is sent when the poll begins, each time
+// its votes are tallied, and once more when it ends, with IsEnded set
+type PayloadPoll struct {
+	PollId  string            `json:"poll_id"`
+	Title   string            `json:"title"`
+	Choices []core.PollChoice `json:"choices"`
+	// EndsAt is the time at which voting closes, or closed if the poll has ended
+	EndsAt  time.Time `json:"ends_at"`
+	IsEnded bool      `json:"is_ended"`
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golden-vcr/schemas/core"
 	"github.com/stretchr/testify/assert"
@@ -180,6 +181,61 @@ func Test_Event(t *testing.T) {
 			},
 			`{"type":"toast","payload":{"type":"gifted-subs","viewer":null,"data":{"num_subscriptions":5}}}`,
 		},
+		{
+			"onscreen toast for a broadcaster that just shouted us out",
+			Event{
+				Type: EventTypeToast,
+				Payload: Payload{
+					Toast: &PayloadToast{
+						Type: ToastTypeShoutedOut,
+						Viewer: &core.Viewer{
+							TwitchUserId:      "90790024",
+							TwitchDisplayName: "wasabimilkshake",
+						},
+						Data: &ToastData{
+							ShoutedOut: &ToastDataShoutedOut{
+								NumViewers: 41,
+							},
+						},
+					},
+				},
+			},
+			`{"type":"toast","payload":{"type":"shouted-out","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"data":{"num_viewers":41}}}`,
+		},
+		{
+			"live results of a poll",
+			Event{
+				Type: EventTypePoll,
+				Payload: Payload{
+					Poll: &PayloadPoll{
+						PollId: "1243456",
+						Title:  "Which tape should we watch next?",
+						Choices: []core.PollChoice{
+							{Id: "123", Title: "Tape 50", NumVotes: 12, NumChannelPointsVotes: 4},
+							{Id: "124", Title: "Tape 51", NumVotes: 7},
+						},
+						EndsAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					},
+				},
+			},
+			`{"type":"poll","payload":{"poll_id":"1243456","title":"Which tape should we watch next?","choices":[{"id":"123","title":"Tape 50","num_votes":12,"num_channel_points_votes":4},{"id":"124","title":"Tape 51","num_votes":7,"num_channel_points_votes":0}],"ends_at":"2024-01-02T03:04:05Z","is_ended":false}}`,
+		},
+		{
+			"progress toward a follower goal",
+			Event{
+				Type: EventTypeGoal,
+				Payload: Payload{
+					Goal: &PayloadGoal{
+						GoalId:        "12345-cool-event",
+						Type:          core.GoalTypeFollow,
+						Description:   "Road to 500 followers",
+						CurrentAmount: 120,
+						TargetAmount:  500,
+					},
+				},
+			},
+			`{"type":"goal","payload":{"goal_id":"12345-cool-event","type":"follow","description":"Road to 500 followers","current_amount":120,"target_amount":500,"is_ended":false,"is_achieved":false}}`,
+		},
 		{
 			"playback of a static image alert",
 			Event{
@@ -265,3 +321,9 @@ func Test_Event(t *testing.T) {
 		})
 	}
 }
+
+func Test_unions(t *testing.T) {
+	assert.NoError(t, payloadUnion.Check())
+	assert.NoError(t, toastDataUnion.Check())
+	assert.NoError(t, imageDetailsUnion.Check())
+}
@@ -25,6 +25,7 @@ const (
 	ToastTypeSubscribed   ToastType = "subscribed"
 	ToastTypeResubscribed ToastType = "resubscribed"
 	ToastTypeGiftedSubs   ToastType = "gifted-subs"
+	ToastTypeShoutedOut   ToastType = "shouted-out"
 )
 
 // ToastData contains toast-type-specific details describing the notification we want
@@ -34,52 +35,60 @@ type ToastData struct {
 	Cheered      *ToastDataCheered
 	Resubscribed *ToastDataResubscribed
 	GiftedSubs   *ToastDataGiftedSubs
+	ShoutedOut   *ToastDataShoutedOut
 }
 
+// toastDataUnion registers every ToastType against the ToastData field that carries its
+// data, if any
+var toastDataUnion = core.NewUnion[ToastType, ToastData]("type", "data").
+	Empty(ToastTypeFollowed).
+	Variant(ToastTypeRaided, func(d *ToastData) any { return &d.Raided }).
+	Variant(ToastTypeCheered, func(d *ToastData) any { return &d.Cheered }).
+	Empty(ToastTypeSubscribed).
+	Variant(ToastTypeResubscribed, func(d *ToastData) any { return &d.Resubscribed }).
+	Variant(ToastTypeGiftedSubs, func(d *ToastData) any { return &d.GiftedSubs }).
+	Variant(ToastTypeShoutedOut, func(d *ToastData) any { return &d.ShoutedOut })
+
 func (p *PayloadToast) UnmarshalJSON(data []byte) error {
+	return p.unmarshal(data, false)
+}
+
+// UnmarshalJSONStrict decodes a PayloadToast in strict mode, failing with
+// core.ErrUnknownType if the toast type is not recognized, or with
+// core.ErrMissingPayload if the toast type requires data and none is present
+func (p *PayloadToast) UnmarshalJSONStrict(data []byte) error {
+	return p.unmarshal(data, true)
+}
+
+func (p *PayloadToast) unmarshal(data []byte, strict bool) error {
 	type fields struct {
 		Type   ToastType       `json:"type"`
 		Viewer *core.Viewer    `json:"viewer"`
 		Data   json.RawMessage `json:"data"`
 	}
 	var f fields
-	if err := json.Unmarshal(data, &f); err != nil {
+	if err := core.DecodeJSON(data, &f, strict); err != nil {
 		return err
 	}
 
 	p.Type = f.Type
 	p.Viewer = f.Viewer
-	switch f.Type {
-	case ToastTypeRaided:
-		p.Data = &ToastData{}
-		return json.Unmarshal(f.Data, &p.Data.Raided)
-	case ToastTypeCheered:
-		p.Data = &ToastData{}
-		return json.Unmarshal(f.Data, &p.Data.Cheered)
-	case ToastTypeResubscribed:
-		p.Data = &ToastData{}
-		return json.Unmarshal(f.Data, &p.Data.Resubscribed)
-	case ToastTypeGiftedSubs:
-		p.Data = &ToastData{}
-		return json.Unmarshal(f.Data, &p.Data.GiftedSubs)
+	var toastData ToastData
+	if err := toastDataUnion.Unmarshal(f.Type, f.Data, &toastData, strict); err != nil {
+		return err
+	}
+	if toastDataUnion.HasPayload(f.Type) {
+		p.Data = &toastData
 	}
 	return nil
 }
 
 func (d ToastData) MarshalJSON() ([]byte, error) {
-	if d.Raided != nil {
-		return json.Marshal(d.Raided)
-	}
-	if d.Cheered != nil {
-		return json.Marshal(d.Cheered)
-	}
-	if d.Resubscribed != nil {
-		return json.Marshal(d.Resubscribed)
-	}
-	if d.GiftedSubs != nil {
-		return json.Marshal(d.GiftedSubs)
-	}
-	return json.Marshal(nil)
+	return toastDataUnion.Marshal(d)
+}
+
+func (ToastData) Union() core.UnionInfo {
+	return toastDataUnion
 }
 
 type ToastDataRaided struct {
@@ -99,3 +108,9 @@ type ToastDataResubscribed struct {
 type ToastDataGiftedSubs struct {
 	NumSubscriptions int `json:"num_subscriptions"`
 }
+
+// ToastDataShoutedOut describes a shoutout that we've received from another
+// broadcaster, who is identified as the toast's Viewer
+type ToastDataShoutedOut struct {
+	NumViewers int `json:"num_viewers"`
+}
@@ -0,0 +1,123 @@
+package eonscreen
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/golden-vcr/schemas/core"
+)
+
+// Validate verifies that an Event is well-formed: its type must be recognized, and it
+// must carry a valid payload of the corresponding type (and no other)
+func (e Event) Validate() error {
+	var v core.Validator
+	v.Nested("", payloadUnion.Validate(e.Type, &e.Payload))
+	return v.Err()
+}
+
+func (p PayloadStatus) Validate() error {
+	var v core.Validator
+	v.Check(p.CurrentTapeId >= 0, "/current_tape_id", "must not be negative")
+	return v.Err()
+}
+
+// Validate verifies that a PayloadToast is well-formed: its type must be recognized, it
+// must carry the data required by that type (and no other), and it must identify a
+// viewer unless the toast type permits anonymity
+func (p PayloadToast) Validate() error {
+	var v core.Validator
+	data := p.Data
+	if data == nil {
+		data = &ToastData{}
+	}
+	v.Nested("", toastDataUnion.Validate(p.Type, data))
+	if p.Viewer != nil {
+		v.Nested("/viewer", p.Viewer.Validate())
+	} else {
+		v.Check(p.Type == ToastTypeCheered || p.Type == ToastTypeGiftedSubs, "/viewer", "is required")
+	}
+	return v.Err()
+}
+
+func (d ToastDataRaided) Validate() error {
+	var v core.Validator
+	v.Check(d.NumViewers >= 0, "/num_viewers", "must not be negative")
+	return v.Err()
+}
+
+func (d ToastDataCheered) Validate() error {
+	var v core.Validator
+	v.Check(d.NumBits > 0, "/num_bits", "must be a positive number")
+	return v.Err()
+}
+
+func (d ToastDataResubscribed) Validate() error {
+	var v core.Validator
+	v.Check(d.NumCumulativeMonths > 0, "/num_cumulative_months", "must be a positive number")
+	return v.Err()
+}
+
+func (d ToastDataGiftedSubs) Validate() error {
+	var v core.Validator
+	v.Check(d.NumSubscriptions > 0, "/num_subscriptions", "must be a positive number")
+	return v.Err()
+}
+
+func (d ToastDataShoutedOut) Validate() error {
+	var v core.Validator
+	v.Check(d.NumViewers >= 0, "/num_viewers", "must not be negative")
+	return v.Err()
+}
+
+// Validate verifies that a PayloadImage is well-formed: its type must be recognized,
+// and it must carry valid details of the corresponding type (and no other)
+func (p PayloadImage) Validate() error {
+	var v core.Validator
+	v.Nested("", imageDetailsUnion.Validate(p.Type, &p.Details))
+	v.Nested("/viewer", p.Viewer.Validate())
+	return v.Err()
+}
+
+func (d ImageDetailsStatic) Validate() error {
+	var v core.Validator
+	v.Check(d.ImageId != "", "/image_id", "is required")
+	return v.Err()
+}
+
+func (d ImageDetailsGhost) Validate() error {
+	var v core.Validator
+	v.Check(d.ImageUrl != "", "/image_url", "is required")
+	v.Check(d.Description != "", "/description", "is required")
+	return v.Err()
+}
+
+var hexColorRegexp = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+func (d ImageDetailsFriend) Validate() error {
+	var v core.Validator
+	v.Check(d.ImageUrl != "", "/image_url", "is required")
+	v.Check(d.Description != "", "/description", "is required")
+	v.Check(d.Name != "", "/name", "is required")
+	v.Check(hexColorRegexp.MatchString(d.BackgroundColor), "/background_color", "must be a hex color of the form '#rrggbb'")
+	return v.Err()
+}
+
+func (p PayloadPoll) Validate() error {
+	var v core.Validator
+	v.Check(p.PollId != "", "/poll_id", "is required")
+	v.Check(len(p.Choices) > 0, "/choices", "must not be empty")
+	for i, c := range p.Choices {
+		v.Nested(fmt.Sprintf("/choices/%d", i), c.Validate())
+	}
+	return v.Err()
+}
+
+func (p PayloadGoal) Validate() error {
+	var v core.Validator
+	v.Check(p.GoalId != "", "/goal_id", "is required")
+	v.Nested("/type", p.Type.Validate())
+	v.Check(p.CurrentAmount >= 0, "/current_amount", "must not be negative")
+	v.Check(p.TargetAmount > 0, "/target_amount", "must be a positive number")
+	v.Check(p.IsEnded || !p.IsAchieved, "/is_achieved", "must be false until the goal has ended")
+	return v.Err()
+}
@@ -0,0 +1,160 @@
+package eonscreen
+
+import (
+	"testing"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Event_Validate(t *testing.T) {
+	viewer := core.Viewer{
+		TwitchUserId:      "90790024",
+		TwitchDisplayName: "wasabimilkshake",
+	}
+	tests := []struct {
+		name    string
+		ev      Event
+		wantErr core.ValidationErrors
+	}{
+		{
+			"status",
+			Event{Type: EventTypeStatus, Payload: Payload{Status: &PayloadStatus{CurrentTapeId: 50}}},
+			nil,
+		},
+		{
+			"anonymous cheer toast",
+			Event{
+				Type: EventTypeToast,
+				Payload: Payload{
+					Toast: &PayloadToast{
+						Type: ToastTypeCheered,
+						Data: &ToastData{Cheered: &ToastDataCheered{NumBits: 200}},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"poll with invalid choice",
+			Event{
+				Type: EventTypePoll,
+				Payload: Payload{
+					Poll: &PayloadPoll{
+						PollId: "1243456",
+						Title:  "Which tape should we watch next?",
+						Choices: []core.PollChoice{
+							{Id: "123", Title: "Tape 50", NumVotes: 12},
+							{Title: "Tape 51", NumVotes: -1},
+						},
+					},
+				},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/choices/1/id", Message: "is required"},
+				{Path: "/payload/choices/1/num_votes", Message: "must not be negative"},
+			},
+		},
+		{
+			"goal with unknown type and negative progress",
+			Event{
+				Type: EventTypeGoal,
+				Payload: Payload{
+					Goal: &PayloadGoal{
+						GoalId:        "12345-cool-event",
+						Type:          "new_raider",
+						CurrentAmount: -1,
+						TargetAmount:  500,
+					},
+				},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/type", Message: "'new_raider' is not a valid goal type"},
+				{Path: "/payload/current_amount", Message: "must not be negative"},
+			},
+		},
+		{
+			"status with no payload",
+			Event{Type: EventTypeStatus},
+			core.ValidationErrors{{Path: "/payload", Message: "is required for type 'status'"}},
+		},
+		{
+			"follow toast with no viewer",
+			Event{Type: EventTypeToast, Payload: Payload{Toast: &PayloadToast{Type: ToastTypeFollowed}}},
+			core.ValidationErrors{{Path: "/payload/viewer", Message: "is required"}},
+		},
+		{
+			"cheer toast with negative bits",
+			Event{
+				Type: EventTypeToast,
+				Payload: Payload{
+					Toast: &PayloadToast{
+						Type:   ToastTypeCheered,
+						Viewer: &viewer,
+						Data:   &ToastData{Cheered: &ToastDataCheered{NumBits: -1}},
+					},
+				},
+			},
+			core.ValidationErrors{{Path: "/payload/data/num_bits", Message: "must be a positive number"}},
+		},
+		{
+			"toast data that doesn't match toast type",
+			Event{
+				Type: EventTypeToast,
+				Payload: Payload{
+					Toast: &PayloadToast{
+						Type:   ToastTypeSubscribed,
+						Viewer: &viewer,
+						Data:   &ToastData{Raided: &ToastDataRaided{NumViewers: 5}},
+					},
+				},
+			},
+			core.ValidationErrors{{Path: "/payload/data", Message: "Raided does not match type 'subscribed'"}},
+		},
+		{
+			"friend image with missing details",
+			Event{
+				Type: EventTypeImage,
+				Payload: Payload{
+					Image: &PayloadImage{
+						Type:   ImageTypeFriend,
+						Viewer: viewer,
+						Details: ImageDetails{
+							Friend: &ImageDetailsFriend{
+								ImageUrl:        "https://my-cool-images.biz/seal.jpg",
+								Description:     "a seal",
+								BackgroundColor: "yellow",
+							},
+						},
+					},
+				},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/details/name", Message: "is required"},
+				{Path: "/payload/details/background_color", Message: "must be a hex color of the form '#rrggbb'"},
+			},
+		},
+		{
+			"image with unknown type and anonymous viewer",
+			Event{
+				Type:    EventTypeImage,
+				Payload: Payload{Image: &PayloadImage{Type: "animated"}},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/type", Message: "unknown type 'animated'"},
+				{Path: "/payload/viewer/twitch_user_id", Message: "is required"},
+				{Path: "/payload/viewer/twitch_display_name", Message: "is required"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ev.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.wantErr, err)
+			}
+		})
+	}
+}
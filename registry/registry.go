@@ -0,0 +1,121 @@
+// Package registry maps the name of each queue to the schema of the messages that are
+// produced to it, so that tools which handle messages from arbitrary queues (e.g. for
+// inspecting dead-lettered messages or audit logs) can decode them without knowing
+// their Go types in advance.
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	ebroadcast "github.com/golden-vcr/schemas/broadcast-events"
+	"github.com/golden-vcr/schemas/core"
+	genreq "github.com/golden-vcr/schemas/generation-requests"
+	eonscreen "github.com/golden-vcr/schemas/onscreen-events"
+	etwitch "github.com/golden-vcr/schemas/twitch-events"
+)
+
+// Queue is the name of a RabbitMQ queue that carries messages described by one of the
+// schemas in this module
+type Queue string
+
+const (
+	QueueTwitchEvents       Queue = "twitch-events"
+	QueueBroadcastEvents    Queue = "broadcast-events"
+	QueueOnscreenEvents     Queue = "onscreen-events"
+	QueueGenerationRequests Queue = "generation-requests"
+)
+
+// ErrUnknownQueue is returned when attempting to decode a message from a queue that is
+// not registered
+var ErrUnknownQueue = errors.New("unknown queue")
+
+// Schema describes the messages that are produced to a single queue
+type Schema struct {
+	// Queue is the name of the queue
+	Queue Queue
+	// Root is the type of the body of each message, e.g. etwitch.Event
+	Root reflect.Type
+	// SchemaVersion is the current revision of the schema, as recorded in the header
+	// of every enveloped message
+	SchemaVersion int
+
+	decode func(data []byte, strict bool) (any, string, error)
+}
+
+// schemas registers every queue, in the order in which they're documented
+var schemas = []Schema{
+	register(QueueTwitchEvents, etwitch.SchemaVersion, etwitch.Unwrap, etwitch.UnwrapStrict,
+		func(ev etwitch.Event) string { return string(ev.Type) }),
+	register(QueueBroadcastEvents, ebroadcast.SchemaVersion, ebroadcast.Unwrap, ebroadcast.UnwrapStrict,
+		func(ev ebroadcast.Event) string { return string(ev.Type) }),
+	register(QueueOnscreenEvents, eonscreen.SchemaVersion, eonscreen.Unwrap, eonscreen.UnwrapStrict,
+		func(ev eonscreen.Event) string { return string(ev.Type) }),
+	register(QueueGenerationRequests, genreq.SchemaVersion, genreq.Unwrap, genreq.UnwrapStrict,
+		func(req genreq.Request) string { return string(req.Type) }),
+}
+
+func register[T any](
+	queue Queue,
+	schemaVersion int,
+	unwrap func(data []byte) (*core.Envelope[T], error),
+	unwrapStrict func(data []byte) (*core.Envelope[T], error),
+	discriminator func(body T) string,
+) Schema {
+	return Schema{
+		Queue:         queue,
+		Root:          reflect.TypeOf((*T)(nil)).Elem(),
+		SchemaVersion: schemaVersion,
+		decode: func(data []byte, strict bool) (any, string, error) {
+			f := unwrap
+			if strict {
+				f = unwrapStrict
+			}
+			envelope, err := f(data)
+			if err != nil {
+				return nil, "", err
+			}
+			return envelope, discriminator(envelope.Body), nil
+		},
+	}
+}
+
+// Schemas returns the schema of every registered queue
+func Schemas() []Schema {
+	return append([]Schema(nil), schemas...)
+}
+
+// Lookup returns the schema of the given queue, or false if the queue is not registered
+func Lookup(queue Queue) (Schema, bool) {
+	for _, schema := range schemas {
+		if schema.Queue == queue {
+			return schema, true
+		}
+	}
+	return Schema{}, false
+}
+
+// Decode decodes a message consumed from the given queue, using the Unwrap function of
+// the corresponding schema package. The returned value is a *core.Envelope[T], where T
+// is the queue's root type (e.g. *core.Envelope[etwitch.Event] for 'twitch-events'),
+// and the returned string is the value of the body's discriminator (e.g. the Event's
+// type).
+func Decode(queue Queue, data []byte) (any, string, error) {
+	return decode(queue, data, false)
+}
+
+// DecodeStrict decodes a message exactly as with Decode, except that the message is
+// decoded in strict mode, using the UnwrapStrict function of the corresponding schema
+// package
+func DecodeStrict(queue Queue, data []byte) (any, string, error) {
+	return decode(queue, data, true)
+}
+
+func decode(queue Queue, data []byte, strict bool) (any, string, error) {
+	schema, ok := Lookup(queue)
+	if !ok {
+		return nil, "", fmt.Errorf("%w: '%s'", ErrUnknownQueue, queue)
+	}
+	return schema.decode(data, strict)
+}
@@ -0,0 +1,126 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	ebroadcast "github.com/golden-vcr/schemas/broadcast-events"
+	"github.com/golden-vcr/schemas/core"
+	genreq "github.com/golden-vcr/schemas/generation-requests"
+	eonscreen "github.com/golden-vcr/schemas/onscreen-events"
+	etwitch "github.com/golden-vcr/schemas/twitch-events"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Schemas(t *testing.T) {
+	queues := []Queue{}
+	for _, schema := range Schemas() {
+		queues = append(queues, schema.Queue)
+	}
+	assert.Equal(t, []Queue{
+		QueueTwitchEvents,
+		QueueBroadcastEvents,
+		QueueOnscreenEvents,
+		QueueGenerationRequests,
+	}, queues)
+
+	schema, ok := Lookup(QueueOnscreenEvents)
+	assert.True(t, ok)
+	assert.Equal(t, "Event", schema.Root.Name())
+	assert.Equal(t, eonscreen.SchemaVersion, schema.SchemaVersion)
+
+	_, ok = Lookup("nonexistent-events")
+	assert.False(t, ok)
+}
+
+func Test_Decode(t *testing.T) {
+	tests := []struct {
+		name              string
+		queue             Queue
+		data              string
+		wantBody          any
+		wantDiscriminator string
+	}{
+		{
+			"bare twitch event",
+			QueueTwitchEvents,
+			`{"type":"viewer-raided","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"num_raiders":42}}`,
+			etwitch.Event{
+				Type:    etwitch.EventTypeViewerRaided,
+				Viewer:  &core.Viewer{TwitchUserId: "90790024", TwitchDisplayName: "wasabimilkshake"},
+				Payload: &etwitch.Payload{ViewerRaided: &etwitch.PayloadViewerRaided{NumRaiders: 42}},
+			},
+			"viewer-raided",
+		},
+		{
+			"enveloped broadcast event",
+			QueueBroadcastEvents,
+			`{"message_id":"00000000-0000-0000-0000-000000000001","correlation_id":"00000000-0000-0000-0000-000000000001","produced_at":"1997-09-01T12:00:00Z","producer":"broadcasts","schema_version":1,"body":{"type":"broadcast-finished","broadcast":{"id":55,"started_at":"1997-09-01T12:00:00Z"}}}`,
+			ebroadcast.Event{
+				Type:      ebroadcast.EventTypeBroadcastFinished,
+				Broadcast: ebroadcast.BroadcastData{Id: 55, StartedAt: time.Date(1997, 9, 1, 12, 0, 0, 0, time.UTC)},
+			},
+			"broadcast-finished",
+		},
+		{
+			"bare onscreen event",
+			QueueOnscreenEvents,
+			`{"type":"toast","payload":{"type":"followed","viewer":null}}`,
+			eonscreen.Event{
+				Type:    eonscreen.EventTypeToast,
+				Payload: eonscreen.Payload{Toast: &eonscreen.PayloadToast{Type: eonscreen.ToastTypeFollowed}},
+			},
+			"toast",
+		},
+		{
+			"bare generation request",
+			QueueGenerationRequests,
+			`{"type":"image","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"state":{"broadcast_id":0,"screening_id":"00000000-0000-0000-0000-000000000000","tape_id":0},"payload":{"style":"ghost","inputs":{"subject":"a seal"}}}`,
+			genreq.Request{
+				Type:   genreq.RequestTypeImage,
+				Viewer: core.Viewer{TwitchUserId: "90790024", TwitchDisplayName: "wasabimilkshake"},
+				Payload: genreq.Payload{Image: &genreq.PayloadImage{
+					Style:  genreq.ImageStyleGhost,
+					Inputs: genreq.ImageInputs{Ghost: &genreq.ImageInputsGhost{Subject: "a seal"}},
+				}},
+			},
+			"image",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, discriminator, err := Decode(tt.queue, []byte(tt.data))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDiscriminator, discriminator)
+			switch envelope := got.(type) {
+			case *core.Envelope[etwitch.Event]:
+				assert.Equal(t, tt.wantBody, envelope.Body)
+			case *core.Envelope[ebroadcast.Event]:
+				assert.Equal(t, tt.wantBody, envelope.Body)
+				assert.Equal(t, "broadcasts", envelope.Producer)
+			case *core.Envelope[eonscreen.Event]:
+				assert.Equal(t, tt.wantBody, envelope.Body)
+			case *core.Envelope[genreq.Request]:
+				assert.Equal(t, tt.wantBody, envelope.Body)
+			default:
+				t.Fatalf("unexpected type %T", got)
+			}
+		})
+	}
+}
+
+func Test_Decode_errors(t *testing.T) {
+	t.Run("unknown queue", func(t *testing.T) {
+		_, _, err := Decode("nonexistent-events", []byte(`{}`))
+		assert.ErrorIs(t, err, ErrUnknownQueue)
+	})
+	t.Run("unknown type is accepted in non-strict mode", func(t *testing.T) {
+		_, discriminator, err := Decode(QueueTwitchEvents, []byte(`{"type":"viewer-danced","viewer":null,"payload":null}`))
+		assert.NoError(t, err)
+		assert.Equal(t, "viewer-danced", discriminator)
+	})
+	t.Run("unknown type is rejected in strict mode", func(t *testing.T) {
+		_, _, err := DecodeStrict(QueueTwitchEvents, []byte(`{"type":"viewer-danced","viewer":null,"payload":null}`))
+		assert.ErrorIs(t, err, core.ErrUnknownType)
+	})
+}
@@ -1,4 +1,6 @@
 // Package etwitch defines the schema for events that describe actions occurring on
 // Twitch (e.g. broadcast state changes, viewer interactions), and it provides code for
-// constructing those events in response to EventSub callbacks
+// constructing those events in response to EventSub notifications, whether they're
+// received via webhook callbacks or via a WebSocket connection, and in response to
+// messages received from Twitch chat via IRC
 package etwitch
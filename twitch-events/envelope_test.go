@@ -0,0 +1,156 @@
+package etwitch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Unwrap(t *testing.T) {
+	ev := Event{
+		Type: EventTypeViewerCheered,
+		Viewer: &core.Viewer{
+			TwitchUserId:      "90790024",
+			TwitchDisplayName: "wasabimilkshake",
+		},
+		Payload: &Payload{
+			ViewerCheered: &PayloadViewerCheered{
+				NumBits: 200,
+				Message: "ghost of a seal",
+			},
+		},
+	}
+	t.Run("enveloped event", func(t *testing.T) {
+		data, err := json.Marshal(Wrap(ev, "hooks"))
+		assert.NoError(t, err)
+
+		got, err := Unwrap(data)
+		assert.NoError(t, err)
+		assert.Equal(t, "hooks", got.Producer)
+		assert.Equal(t, SchemaVersion, got.SchemaVersion)
+		assert.Equal(t, ev, got.Body)
+	})
+	t.Run("bare event", func(t *testing.T) {
+		data, err := json.Marshal(ev)
+		assert.NoError(t, err)
+
+		got, err := Unwrap(data)
+		assert.NoError(t, err)
+		assert.True(t, got.IsBare())
+		assert.Equal(t, ev, got.Body)
+	})
+}
+
+func Test_UnwrapStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{
+			"bare event with no payload",
+			`{"type":"viewer-followed","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":null}`,
+			nil,
+		},
+		{
+			"enveloped event with payload",
+			`{"message_id":"5bc71c8b-4d42-4b80-8a6b-7fd2bde0b1c2","correlation_id":"5bc71c8b-4d42-4b80-8a6b-7fd2bde0b1c2","produced_at":"1997-09-01T12:00:00Z","producer":"hooks","schema_version":1,"body":{"type":"viewer-raided","viewer":null,"payload":{"num_raiders":42}}}`,
+			nil,
+		},
+		{
+			"bare stream-started event from a producer that predates its payload",
+			`{"type":"stream-started","viewer":null,"payload":null}`,
+			nil,
+		},
+		{
+			"v1 stream-ended event from a producer that predates its payload",
+			`{"message_id":"5bc71c8b-4d42-4b80-8a6b-7fd2bde0b1c2","correlation_id":"5bc71c8b-4d42-4b80-8a6b-7fd2bde0b1c2","produced_at":"1997-09-01T12:00:00Z","producer":"hooks","schema_version":1,"body":{"type":"stream-ended","viewer":null,"payload":null}}`,
+			nil,
+		},
+		{
+			"bare stream-started event with no payload key",
+			`{"type":"stream-started","viewer":null}`,
+			nil,
+		},
+		{
+			"bare stream-hype-started event from a producer that predates its payload",
+			`{"type":"stream-hype-started","viewer":null,"payload":null}`,
+			nil,
+		},
+		{
+			"bare stream-hype-started event with no payload key",
+			`{"type":"stream-hype-started","viewer":null}`,
+			nil,
+		},
+		{
+			"unknown event type",
+			`{"type":"viewer-sneezed","viewer":null,"payload":null}`,
+			core.ErrUnknownType,
+		},
+		{
+			"null payload for event type that requires one",
+			`{"type":"viewer-cheered","viewer":null,"payload":null}`,
+			core.ErrMissingPayload,
+		},
+		{
+			"absent payload for event type that requires one",
+			`{"message_id":"5bc71c8b-4d42-4b80-8a6b-7fd2bde0b1c2","correlation_id":"5bc71c8b-4d42-4b80-8a6b-7fd2bde0b1c2","produced_at":"1997-09-01T12:00:00Z","producer":"hooks","schema_version":1,"body":{"type":"viewer-cheered","viewer":null}}`,
+			core.ErrMissingPayload,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnwrapStrict([]byte(tt.data))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, got)
+			}
+		})
+	}
+	t.Run("stream-started event that predates its payload is valid", func(t *testing.T) {
+		got, err := UnwrapStrict([]byte(`{"type":"stream-started","viewer":null,"payload":null}`))
+		assert.NoError(t, err)
+		assert.Equal(t, EventTypeStreamStarted, got.Body.Type)
+		assert.Nil(t, got.Body.Payload.StreamStarted)
+		assert.NoError(t, got.Body.Validate())
+	})
+	t.Run("stream-hype-started event that predates its payload is valid", func(t *testing.T) {
+		got, err := UnwrapStrict([]byte(`{"type":"stream-hype-started","viewer":null,"payload":null}`))
+		assert.NoError(t, err)
+		assert.Equal(t, EventTypeStreamHypeStarted, got.Body.Type)
+		assert.Nil(t, got.Body.Payload.StreamHypeStarted)
+		assert.NoError(t, got.Body.Validate())
+	})
+	t.Run("events with no payload key are accepted in either mode", func(t *testing.T) {
+		for _, eventType := range []EventType{EventTypeStreamStarted, EventTypeStreamHypeStarted} {
+			data := []byte(`{"type":"` + eventType + `","viewer":null}`)
+			for _, unwrap := range []func([]byte) (*core.Envelope[Event], error){Unwrap, UnwrapStrict} {
+				got, err := unwrap(data)
+				assert.NoError(t, err)
+				assert.Equal(t, eventType, got.Body.Type)
+				assert.NoError(t, got.Body.Validate())
+			}
+		}
+	})
+	t.Run("payload that doesn't match event type", func(t *testing.T) {
+		got, err := UnwrapStrict([]byte(`{"type":"viewer-cheered","viewer":null,"payload":{"num_bits":"lots"}}`))
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+	t.Run("unrecognized fields are ignored", func(t *testing.T) {
+		got, err := UnwrapStrict([]byte(`{"type":"viewer-cheered","viewer":null,"payload":{"num_bits":100,"message":"cheer100","color":"red"},"extra":true}`))
+		assert.NoError(t, err)
+		assert.Equal(t, 100, got.Body.Payload.ViewerCheered.NumBits)
+	})
+	t.Run("non-strict unwrap accepts malformed events", func(t *testing.T) {
+		got, err := Unwrap([]byte(`{"type":"viewer-sneezed","viewer":null,"payload":null}`))
+		assert.NoError(t, err)
+		assert.Equal(t, EventType("viewer-sneezed"), got.Body.Type)
+		assert.Nil(t, got.Body.Payload)
+	})
+}
@@ -9,7 +9,13 @@ import (
 
 var ErrUnsupportedEventSubType = errors.New("unsupported EventSub type")
 
-func FromEventSub(subscription *helix.EventSubSubscription, data json.RawMessage) (*Event, error) {
+// EventSub subscription types that FromEventSub supports but helix does not define
+const (
+	eventSubTypeChannelChatMessageDelete = "channel.chat.message_delete"
+)
+
+func FromEventSub(subscription *helix.EventSubSubscription, data json.RawMessage, opts ...ConvertOption) (*Event, error) {
+	o := newConvertOptions(opts)
 	switch subscription.Type {
 	case helix.EventSubTypeStreamOnline:
 		return fromStreamOnlineEvent(data)
@@ -17,18 +23,56 @@ func FromEventSub(subscription *helix.EventSubSubscription, data json.RawMessage
 		return fromStreamOfflineEvent(data)
 	case helix.EventSubTypeHypeTrainBegin:
 		return fromHypeTrainBeginEvent(data)
+	case helix.EventSubTypeHypeTrainProgress:
+		return fromHypeTrainProgressEvent(data)
+	case helix.EventSubTypeHypeTrainEnd:
+		return fromHypeTrainEndEvent(data)
+	case helix.EventSubTypeChannelPollBegin:
+		return fromChannelPollBeginEvent(data)
+	case helix.EventSubTypeChannelPollProgress:
+		return fromChannelPollProgressEvent(data)
+	case helix.EventSubTypeChannelPollEnd:
+		return fromChannelPollEndEvent(data)
+	case helix.EventSubTypeChannelPredictionBegin:
+		return fromChannelPredictionBeginEvent(data)
+	case helix.EventSubTypeChannelPredictionProgress:
+		return fromChannelPredictionProgressEvent(data)
+	case helix.EventSubTypeChannelPredictionLock:
+		return fromChannelPredictionLockEvent(data)
+	case helix.EventSubTypeChannelPredictionEnd:
+		return fromChannelPredictionEndEvent(data)
+	case helix.EventSubTypeChannelGoalBegin:
+		return fromChannelGoalBeginEvent(data)
+	case helix.EventSubTypeChannelGoalProgress:
+		return fromChannelGoalProgressEvent(data)
+	case helix.EventSubTypeChannelGoalEnd:
+		return fromChannelGoalEndEvent(data)
+	case helix.EventSubTypeChannelUpdate:
+		return fromChannelUpdateEvent(data)
 	case helix.EventSubTypeChannelFollow:
 		return fromChannelFollowEvent(data)
 	case helix.EventSubTypeChannelRaid:
 		return fromChannelRaidEvent(data)
 	case helix.EventSubTypeChannelCheer:
 		return fromChannelCheerEvent(data)
+	case helix.EventSubTypeChannelPointsCustomRewardRedemptionAdd:
+		return fromChannelPointsCustomRewardRedemptionAddEvent(data)
 	case helix.EventSubTypeChannelSubscription:
-		return fromChannelSubscriptionEvent(data)
+		return fromChannelSubscriptionEvent(data, o.creditMultipliers)
 	case helix.EventSubTypeChannelSubscriptionMessage:
-		return fromChannelSubscriptionMessageEvent(data)
+		return fromChannelSubscriptionMessageEvent(data, o.creditMultipliers)
 	case helix.EventSubTypeChannelSubscriptionGift:
-		return fromChannelSubscriptionGiftEvent(data)
+		return fromChannelSubscriptionGiftEvent(data, o.creditMultipliers)
+	case helix.EventSubShoutoutCreate:
+		return fromChannelShoutoutCreateEvent(data)
+	case helix.EventSubShoutoutReceive:
+		return fromChannelShoutoutReceiveEvent(data)
+	case helix.EventSubTypeChannelBan:
+		return fromChannelBanEvent(data)
+	case helix.EventSubTypeChannelUnban:
+		return fromChannelUnbanEvent(data)
+	case eventSubTypeChannelChatMessageDelete:
+		return fromChannelChatMessageDeleteEvent(data)
 	default:
 		return nil, ErrUnsupportedEventSubType
 	}
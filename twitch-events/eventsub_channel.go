@@ -3,11 +3,41 @@ package etwitch
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/golden-vcr/schemas/core"
 	"github.com/nicklaw5/helix/v2"
 )
 
+func fromChannelUpdateEvent(data json.RawMessage) (*Event, error) {
+	// helix.EventSubChannelUpdateEvent predates version 2 of channel.update, which
+	// replaces is_mature with content_classification_labels
+	var ev struct {
+		helix.EventSubChannelUpdateEvent
+		ContentClassificationLabels []string `json:"content_classification_labels"`
+	}
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelUpdateEvent: %w", err)
+	}
+	labels := ev.ContentClassificationLabels
+	if labels == nil {
+		labels = []string{}
+	}
+	return &Event{
+		Type: EventTypeStreamUpdated,
+		Payload: &Payload{
+			StreamUpdated: &PayloadStreamUpdated{
+				BroadcasterId: ev.BroadcasterUserID,
+				Title:         ev.Title,
+				CategoryId:    ev.CategoryID,
+				CategoryName:  ev.CategoryName,
+				Language:      ev.Language,
+				ContentLabels: labels,
+			},
+		},
+	}, nil
+}
+
 func fromChannelFollowEvent(data json.RawMessage) (*Event, error) {
 	var ev helix.EventSubChannelFollowEvent
 	if err := json.Unmarshal(data, &ev); err != nil {
@@ -60,20 +90,41 @@ func fromChannelCheerEvent(data json.RawMessage) (*Event, error) {
 			ViewerCheered: &PayloadViewerCheered{
 				NumBits: ev.Bits,
 				Message: ev.Message,
+				// channel.cheer doesn't report the positions of emotes in the message
+				MessageFragments: parseMessage(ev.Message, nil, true),
+			},
+		},
+	}, nil
+}
+
+func fromChannelPointsCustomRewardRedemptionAddEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubChannelPointsCustomRewardRedemptionEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelPointsCustomRewardRedemptionEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeViewerRedeemedReward,
+		Viewer: &core.Viewer{
+			TwitchUserId:      ev.UserID,
+			TwitchDisplayName: ev.UserName,
+		},
+		Payload: &Payload{
+			ViewerRedeemedReward: &PayloadViewerRedeemedReward{
+				RewardId:    ev.Reward.ID,
+				RewardTitle: ev.Reward.Title,
+				RewardCost:  ev.Reward.Cost,
+				Message:     ev.UserInput,
 			},
 		},
 	}, nil
 }
 
-func fromChannelSubscriptionEvent(data json.RawMessage) (*Event, error) {
+func fromChannelSubscriptionEvent(data json.RawMessage, creditMultipliers CreditMultiplierPolicy) (*Event, error) {
 	var ev helix.EventSubChannelSubscribeEvent
 	if err := json.Unmarshal(data, &ev); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal ChannelSubscribeEvent: %w", err)
 	}
-	creditMultiplier, err := getCreditMultiplierFromTier(ev.Tier)
-	if err != nil {
-		return nil, err
-	}
+	tier := parseSubTier(ev.Tier)
 	if ev.IsGift {
 		return &Event{
 			Type: EventTypeViewerReceivedGiftSub,
@@ -83,7 +134,8 @@ func fromChannelSubscriptionEvent(data json.RawMessage) (*Event, error) {
 			},
 			Payload: &Payload{
 				ViewerReceivedGiftSub: &PayloadViewerReceivedGiftSub{
-					CreditMultiplier: creditMultiplier,
+					Tier:             tier,
+					CreditMultiplier: creditMultipliers.CreditMultiplier(tier),
 				},
 			},
 		}, nil
@@ -96,21 +148,19 @@ func fromChannelSubscriptionEvent(data json.RawMessage) (*Event, error) {
 		},
 		Payload: &Payload{
 			ViewerSubscribed: &PayloadViewerSubscribed{
-				CreditMultiplier: creditMultiplier,
+				Tier:             tier,
+				CreditMultiplier: creditMultipliers.CreditMultiplier(tier),
 			},
 		},
 	}, nil
 }
 
-func fromChannelSubscriptionMessageEvent(data json.RawMessage) (*Event, error) {
+func fromChannelSubscriptionMessageEvent(data json.RawMessage, creditMultipliers CreditMultiplierPolicy) (*Event, error) {
 	var ev helix.EventSubChannelSubscriptionMessageEvent
 	if err := json.Unmarshal(data, &ev); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal ChannelSubscriptionMessageEvent: %w", err)
 	}
-	creditMultiplier, err := getCreditMultiplierFromTier(ev.Tier)
-	if err != nil {
-		return nil, err
-	}
+	tier := parseSubTier(ev.Tier)
 	return &Event{
 		Type: EventTypeViewerResubscribed,
 		Viewer: &core.Viewer{
@@ -119,23 +169,22 @@ func fromChannelSubscriptionMessageEvent(data json.RawMessage) (*Event, error) {
 		},
 		Payload: &Payload{
 			ViewerResubscribed: &PayloadViewerResubscribed{
-				CreditMultiplier:    creditMultiplier,
+				Tier:                tier,
+				CreditMultiplier:    creditMultipliers.CreditMultiplier(tier),
 				NumCumulativeMonths: ev.CumulativeMonths,
 				Message:             ev.Message.Text,
+				MessageFragments:    fromEventSubMessage(ev.Message),
 			},
 		},
 	}, nil
 }
 
-func fromChannelSubscriptionGiftEvent(data json.RawMessage) (*Event, error) {
+func fromChannelSubscriptionGiftEvent(data json.RawMessage, creditMultipliers CreditMultiplierPolicy) (*Event, error) {
 	var ev helix.EventSubChannelSubscriptionGiftEvent
 	if err := json.Unmarshal(data, &ev); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal ChannelSubscriptionGiftEvent: %w", err)
 	}
-	creditMultiplier, err := getCreditMultiplierFromTier(ev.Tier)
-	if err != nil {
-		return nil, err
-	}
+	tier := parseSubTier(ev.Tier)
 	var viewer *core.Viewer
 	if !ev.IsAnonymous {
 		viewer = &core.Viewer{
@@ -148,9 +197,137 @@ func fromChannelSubscriptionGiftEvent(data json.RawMessage) (*Event, error) {
 		Viewer: viewer,
 		Payload: &Payload{
 			ViewerGiftedSubs: &PayloadViewerGiftedSubs{
-				CreditMultiplier: creditMultiplier,
+				Tier:             tier,
+				CreditMultiplier: creditMultipliers.CreditMultiplier(tier),
 				NumSubscriptions: ev.Total,
 			},
 		},
 	}, nil
 }
+
+func fromChannelShoutoutCreateEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubShoutoutCreateEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ShoutoutCreateEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamShoutoutGiven,
+		Payload: &Payload{
+			StreamShoutoutGiven: &PayloadStreamShoutoutGiven{
+				Broadcaster: core.Viewer{
+					TwitchUserId:      ev.ToBroadcasterUserID,
+					TwitchDisplayName: ev.ToBroadcasterUserName,
+				},
+				Moderator: core.Viewer{
+					TwitchUserId:      ev.ModeratorUserID,
+					TwitchDisplayName: ev.ModeratorUserName,
+				},
+				NumViewers:           int(ev.ViewerCount),
+				StartedAt:            ev.StartedAt.Time,
+				CooldownEndsAt:       ev.CooldownEndsAt.Time,
+				TargetCooldownEndsAt: ev.TargetCooldownEndsAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromChannelShoutoutReceiveEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubShoutoutReceiveEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ShoutoutReceiveEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamShoutoutReceived,
+		Payload: &Payload{
+			StreamShoutoutReceived: &PayloadStreamShoutoutReceived{
+				Broadcaster: core.Viewer{
+					TwitchUserId:      ev.FromBroadcasterUserID,
+					TwitchDisplayName: ev.FromBroadcasterUserName,
+				},
+				NumViewers: int(ev.ViewerCount),
+				StartedAt:  ev.StartedAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromChannelBanEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubChannelBanEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelBanEvent: %w", err)
+	}
+	var expiresAt *time.Time
+	if !ev.IsPermanent && !ev.EndsAt.IsZero() {
+		expiresAt = &ev.EndsAt.Time
+	}
+	return &Event{
+		Type: EventTypeViewerBanned,
+		Viewer: &core.Viewer{
+			TwitchUserId:      ev.UserID,
+			TwitchDisplayName: ev.UserName,
+		},
+		Payload: &Payload{
+			ViewerBanned: &PayloadViewerBanned{
+				Moderator: &core.Viewer{
+					TwitchUserId:      ev.ModeratorUserID,
+					TwitchDisplayName: ev.ModeratorUserName,
+				},
+				Reason:    ev.Reason,
+				ExpiresAt: expiresAt,
+			},
+		},
+	}, nil
+}
+
+func fromChannelUnbanEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubChannelUnbanEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelUnbanEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeViewerUnbanned,
+		Viewer: &core.Viewer{
+			TwitchUserId:      ev.UserID,
+			TwitchDisplayName: ev.UserName,
+		},
+		Payload: &Payload{
+			ViewerUnbanned: &PayloadViewerUnbanned{
+				Moderator: core.Viewer{
+					TwitchUserId:      ev.ModeratorUserID,
+					TwitchDisplayName: ev.ModeratorUserName,
+				},
+			},
+		},
+	}, nil
+}
+
+func fromChannelChatMessageDeleteEvent(data json.RawMessage) (*Event, error) {
+	var ev struct {
+		TargetUserID   string `json:"target_user_id"`
+		TargetUserName string `json:"target_user_name"`
+		MessageID      string `json:"message_id"`
+	}
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelChatMessageDeleteEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeViewerMessageDeleted,
+		Viewer: &core.Viewer{
+			TwitchUserId:      ev.TargetUserID,
+			TwitchDisplayName: ev.TargetUserName,
+		},
+		Payload: &Payload{
+			ViewerMessageDeleted: &PayloadViewerMessageDeleted{
+				MessageId: ev.MessageID,
+			},
+		},
+	}, nil
+}
+
+func fromEventSubMessage(message helix.EventSubMessage) MessageFragments {
+	emotes := make([]emoteSpan, 0, len(message.Emotes))
+	for _, emote := range message.Emotes {
+		emotes = append(emotes, emoteSpan{begin: emote.Begin, end: emote.End, id: emote.ID})
+	}
+	return parseMessage(message.Text, emotes, false)
+}
@@ -0,0 +1,208 @@
+package etwitch
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultEventSubDedupeTTL is how long EventSub message IDs are remembered by default:
+// since EventSubWebhook rejects messages older than DefaultEventSubMaxAge, a redelivery
+// can't be accepted once that long has passed since the original delivery
+const DefaultEventSubDedupeTTL = DefaultEventSubMaxAge
+
+// ErrEventSubMessageInFlight is returned by a handler that's been wrapped by an
+// EventSubDeduplicator when a message is redelivered while the original delivery is
+// still being handled, so that Twitch will retry it later rather than consider it
+// handled
+var ErrEventSubMessageInFlight = errors.New("EventSub message is already being handled")
+
+// DedupeStore records which message IDs have recently been seen
+type DedupeStore interface {
+	// Seen returns true if the message with the given ID has been recorded as seen, and
+	// that record hasn't yet expired
+	Seen(ctx context.Context, id string) (bool, error)
+	// MarkSeen records that the message with the given ID has been seen, for the given
+	// duration, returning true if it had already been seen within that duration
+	MarkSeen(ctx context.Context, id string, ttl time.Duration) (bool, error)
+}
+
+// EventSubDeduplicator suppresses duplicate deliveries of the same EventSub message,
+// which occur when Twitch retries a delivery that it believes to have failed
+type EventSubDeduplicator struct {
+	store DedupeStore
+	ttl   time.Duration
+
+	mu       sync.Mutex
+	inFlight map[string]struct{}
+}
+
+// NewEventSubDeduplicator initializes an EventSubDeduplicator that records message IDs
+// in the given store, remembering each ID for the given duration
+func NewEventSubDeduplicator(store DedupeStore, ttl time.Duration) *EventSubDeduplicator {
+	return &EventSubDeduplicator{
+		store:    store,
+		ttl:      ttl,
+		inFlight: make(map[string]struct{}),
+	}
+}
+
+// IsDuplicate returns true if the message with the given ID has already been handled,
+// without recording it as seen: call Wrap to record each message once it's handled
+func (d *EventSubDeduplicator) IsDuplicate(ctx context.Context, id string) (bool, error) {
+	return d.store.Seen(ctx, id)
+}
+
+// Wrap returns a function that calls handle with every notification and revocation
+// that hasn't already been handled, and with every other type of message. A message's
+// ID is recorded as seen only once handle has succeeded, so that it will be handled
+// again if redelivered after a failure. If a message is redelivered while handle is
+// still processing the original delivery, ErrEventSubMessageInFlight is returned. The
+// result can be passed to EventSubWebhook.Handler or EventSubWebSocket.Run.
+func (d *EventSubDeduplicator) Wrap(handle func(ctx context.Context, message *EventSubMessage) error) func(ctx context.Context, message *EventSubMessage) error {
+	return func(ctx context.Context, message *EventSubMessage) error {
+		dedupe := message.Id != "" && (message.Type == EventSubMessageTypeNotification || message.Type == EventSubMessageTypeRevocation)
+		if !dedupe {
+			return handle(ctx, message)
+		}
+
+		if !d.begin(message.Id) {
+			return fmt.Errorf("%w: %s", ErrEventSubMessageInFlight, message.Id)
+		}
+		defer d.end(message.Id)
+
+		seen, err := d.store.Seen(ctx, message.Id)
+		if err != nil {
+			return err
+		}
+		if seen {
+			return nil
+		}
+		if err := handle(ctx, message); err != nil {
+			return err
+		}
+		_, err = d.store.MarkSeen(ctx, message.Id, d.ttl)
+		return err
+	}
+}
+
+// begin records that the message with the given ID is being handled, returning false if
+// it's already being handled
+func (d *EventSubDeduplicator) begin(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.inFlight[id]; ok {
+		return false
+	}
+	d.inFlight[id] = struct{}{}
+	return true
+}
+
+// end records that the message with the given ID is no longer being handled
+func (d *EventSubDeduplicator) end(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.inFlight, id)
+}
+
+// MemoryDedupeStore is a DedupeStore that holds message IDs in memory. It retains no
+// more than a fixed number of IDs: once that capacity is reached, the IDs that are
+// soonest to expire are discarded, even if they haven't yet expired.
+type MemoryDedupeStore struct {
+	capacity int
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // ordered by expiry time, soonest first
+}
+
+type memoryDedupeEntry struct {
+	id        string
+	expiresAt time.Time
+}
+
+// NewMemoryDedupeStore initializes a MemoryDedupeStore that retains up to capacity
+// message IDs. A capacity of less than 1 is treated as 1.
+func NewMemoryDedupeStore(capacity int) *MemoryDedupeStore {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &MemoryDedupeStore{
+		capacity: capacity,
+		now:      time.Now,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (s *MemoryDedupeStore) Seen(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.evict(now)
+	if elem, ok := s.entries[id]; ok {
+		return now.Before(elem.Value.(*memoryDedupeEntry).expiresAt), nil
+	}
+	return false, nil
+}
+
+func (s *MemoryDedupeStore) MarkSeen(ctx context.Context, id string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.evict(now)
+	if elem, ok := s.entries[id]; ok {
+		if now.Before(elem.Value.(*memoryDedupeEntry).expiresAt) {
+			return true, nil
+		}
+		s.remove(elem)
+	}
+
+	s.entries[id] = s.insert(&memoryDedupeEntry{id: id, expiresAt: now.Add(ttl)})
+	for s.order.Len() > s.capacity {
+		s.remove(s.order.Front())
+	}
+	return false, nil
+}
+
+// Len returns the number of message IDs currently retained
+func (s *MemoryDedupeStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+// insert adds an entry to the list, keeping the list ordered by expiry time. Since
+// entries are usually recorded with the same TTL, the position is found by searching
+// from the back of the list. s.mu must be held.
+func (s *MemoryDedupeStore) insert(entry *memoryDedupeEntry) *list.Element {
+	for elem := s.order.Back(); elem != nil; elem = elem.Prev() {
+		if !elem.Value.(*memoryDedupeEntry).expiresAt.After(entry.expiresAt) {
+			return s.order.InsertAfter(entry, elem)
+		}
+	}
+	return s.order.PushFront(entry)
+}
+
+// evict discards expired entries from the front of the list, which holds the entries
+// that are soonest to expire; s.mu must be held
+func (s *MemoryDedupeStore) evict(now time.Time) {
+	for elem := s.order.Front(); elem != nil; elem = s.order.Front() {
+		if now.Before(elem.Value.(*memoryDedupeEntry).expiresAt) {
+			return
+		}
+		s.remove(elem)
+	}
+}
+
+// remove discards a single entry; s.mu must be held
+func (s *MemoryDedupeStore) remove(elem *list.Element) {
+	delete(s.entries, elem.Value.(*memoryDedupeEntry).id)
+	s.order.Remove(elem)
+}
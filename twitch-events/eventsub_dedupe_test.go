@@ -0,0 +1,192 @@
+package etwitch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MemoryDedupeStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 7, 19, 14, 0, 0, 0, time.UTC)
+	newStore := func(capacity int) *MemoryDedupeStore {
+		s := NewMemoryDedupeStore(capacity)
+		s.now = func() time.Time { return now }
+		return s
+	}
+
+	t.Run("repeated IDs are duplicates until they expire", func(t *testing.T) {
+		s := newStore(10)
+		seen, err := s.MarkSeen(ctx, "a", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, seen)
+
+		seen, err = s.MarkSeen(ctx, "a", time.Minute)
+		assert.NoError(t, err)
+		assert.True(t, seen)
+
+		s.now = func() time.Time { return now.Add(time.Minute) }
+		seen, err = s.MarkSeen(ctx, "a", time.Minute)
+		assert.NoError(t, err)
+		assert.False(t, seen)
+		assert.Equal(t, 1, s.Len())
+	})
+	t.Run("expired IDs are evicted", func(t *testing.T) {
+		s := newStore(10)
+		s.MarkSeen(ctx, "a", time.Minute)
+		s.MarkSeen(ctx, "b", time.Minute)
+		assert.Equal(t, 2, s.Len())
+
+		s.now = func() time.Time { return now.Add(2 * time.Minute) }
+		s.MarkSeen(ctx, "c", time.Minute)
+		assert.Equal(t, 1, s.Len())
+	})
+	t.Run("oldest IDs are evicted beyond capacity", func(t *testing.T) {
+		s := newStore(2)
+		s.MarkSeen(ctx, "a", time.Minute)
+		s.MarkSeen(ctx, "b", time.Minute)
+		s.MarkSeen(ctx, "c", time.Minute)
+		assert.Equal(t, 2, s.Len())
+
+		seen, _ := s.MarkSeen(ctx, "a", time.Minute)
+		assert.False(t, seen)
+	})
+	t.Run("IDs are seen once marked, until they expire", func(t *testing.T) {
+		s := newStore(10)
+		seen, err := s.Seen(ctx, "a")
+		assert.NoError(t, err)
+		assert.False(t, seen)
+
+		s.MarkSeen(ctx, "a", time.Minute)
+		seen, err = s.Seen(ctx, "a")
+		assert.NoError(t, err)
+		assert.True(t, seen)
+
+		s.now = func() time.Time { return now.Add(time.Minute) }
+		seen, err = s.Seen(ctx, "a")
+		assert.NoError(t, err)
+		assert.False(t, seen)
+	})
+	t.Run("IDs are evicted in order of expiry", func(t *testing.T) {
+		s := newStore(10)
+		s.MarkSeen(ctx, "long", time.Hour)
+		s.MarkSeen(ctx, "short", time.Minute)
+		s.MarkSeen(ctx, "medium", 10*time.Minute)
+
+		s.now = func() time.Time { return now.Add(2 * time.Minute) }
+		seen, _ := s.Seen(ctx, "medium")
+		assert.True(t, seen)
+		assert.Equal(t, 2, s.Len())
+	})
+	t.Run("IDs that are soonest to expire are evicted beyond capacity", func(t *testing.T) {
+		s := newStore(2)
+		s.MarkSeen(ctx, "long", time.Hour)
+		s.MarkSeen(ctx, "short", time.Minute)
+		s.MarkSeen(ctx, "medium", 10*time.Minute)
+
+		seen, _ := s.Seen(ctx, "long")
+		assert.True(t, seen)
+		seen, _ = s.Seen(ctx, "short")
+		assert.False(t, seen)
+	})
+	t.Run("capacity is at least 1", func(t *testing.T) {
+		for _, capacity := range []int{0, -1} {
+			s := newStore(capacity)
+			s.MarkSeen(ctx, "a", time.Minute)
+			s.MarkSeen(ctx, "b", time.Minute)
+			assert.Equal(t, 1, s.Len())
+			seen, _ := s.Seen(ctx, "b")
+			assert.True(t, seen)
+		}
+	})
+}
+
+func Test_EventSubDeduplicator_Wrap(t *testing.T) {
+	ctx := context.Background()
+	d := NewEventSubDeduplicator(NewMemoryDedupeStore(100), DefaultEventSubDedupeTTL)
+
+	var handled []string
+	fail := false
+	handle := d.Wrap(func(ctx context.Context, message *EventSubMessage) error {
+		if fail {
+			return errors.New("queue unavailable")
+		}
+		handled = append(handled, message.Id)
+		return nil
+	})
+
+	cheer := &EventSubMessage{Id: "cheer", Type: EventSubMessageTypeNotification}
+	follow := &EventSubMessage{Id: "follow", Type: EventSubMessageTypeNotification}
+	welcome := &EventSubMessage{Id: "welcome", Type: EventSubMessageTypeSessionWelcome}
+
+	assert.NoError(t, handle(ctx, cheer))
+	assert.NoError(t, handle(ctx, cheer))
+	assert.NoError(t, handle(ctx, welcome))
+	assert.NoError(t, handle(ctx, welcome))
+
+	fail = true
+	assert.EqualError(t, handle(ctx, follow), "queue unavailable")
+	fail = false
+	assert.NoError(t, handle(ctx, follow))
+	assert.NoError(t, handle(ctx, follow))
+
+	assert.Equal(t, []string{"cheer", "welcome", "welcome", "follow"}, handled)
+}
+
+func Test_EventSubDeduplicator_Wrap_inFlight(t *testing.T) {
+	ctx := context.Background()
+	d := NewEventSubDeduplicator(NewMemoryDedupeStore(100), DefaultEventSubDedupeTTL)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	numHandled := 0
+	handle := d.Wrap(func(ctx context.Context, message *EventSubMessage) error {
+		if numHandled == 0 {
+			close(started)
+			<-release
+		}
+		numHandled++
+		return nil
+	})
+
+	cheer := &EventSubMessage{Id: "cheer", Type: EventSubMessageTypeNotification}
+	done := make(chan error)
+	go func() {
+		done <- handle(ctx, cheer)
+	}()
+	<-started
+
+	// A redelivery that arrives while the original is still being handled is rejected,
+	// so that it won't be acknowledged before the original has succeeded
+	assert.ErrorIs(t, handle(ctx, cheer), ErrEventSubMessageInFlight)
+
+	close(release)
+	assert.NoError(t, <-done)
+	assert.NoError(t, handle(ctx, cheer))
+	assert.Equal(t, 1, numHandled)
+}
+
+func Test_EventSubDeduplicator_IsDuplicate(t *testing.T) {
+	ctx := context.Background()
+	d := NewEventSubDeduplicator(NewMemoryDedupeStore(100), DefaultEventSubDedupeTTL)
+	handle := d.Wrap(func(ctx context.Context, message *EventSubMessage) error {
+		return nil
+	})
+
+	// Checking for a duplicate doesn't record the message as seen, so it's still
+	// handled when it's subsequently delivered
+	isDuplicate, err := d.IsDuplicate(ctx, "cheer")
+	assert.NoError(t, err)
+	assert.False(t, isDuplicate)
+	isDuplicate, err = d.IsDuplicate(ctx, "cheer")
+	assert.NoError(t, err)
+	assert.False(t, isDuplicate)
+
+	assert.NoError(t, handle(ctx, &EventSubMessage{Id: "cheer", Type: EventSubMessageTypeNotification}))
+	isDuplicate, err = d.IsDuplicate(ctx, "cheer")
+	assert.NoError(t, err)
+	assert.True(t, isDuplicate)
+}
@@ -1,21 +1,370 @@
 package etwitch
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/nicklaw5/helix/v2"
+)
 
 func fromStreamOnlineEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubStreamOnlineEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal StreamOnlineEvent: %w", err)
+	}
 	return &Event{
 		Type: EventTypeStreamStarted,
+		Payload: &Payload{
+			StreamStarted: &PayloadStreamStarted{
+				BroadcasterId: ev.BroadcasterUserID,
+				StreamId:      ev.ID,
+				StreamType:    StreamType(ev.Type),
+				StartedAt:     ev.StartedAt.Time,
+			},
+		},
 	}, nil
 }
 
 func fromStreamOfflineEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubStreamOfflineEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal StreamOfflineEvent: %w", err)
+	}
 	return &Event{
 		Type: EventTypeStreamEnded,
+		Payload: &Payload{
+			StreamEnded: &PayloadStreamEnded{
+				BroadcasterId: ev.BroadcasterUserID,
+			},
+		},
 	}, nil
 }
 
 func fromHypeTrainBeginEvent(data json.RawMessage) (*Event, error) {
+	// helix.EventSubHypeTrainBeginEvent omits the level, which Twitch does include
+	var ev struct {
+		helix.EventSubHypeTrainBeginEvent
+		Level int `json:"level"`
+	}
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal HypeTrainBeginEvent: %w", err)
+	}
 	return &Event{
 		Type: EventTypeStreamHypeStarted,
+		Payload: &Payload{
+			StreamHypeStarted: &PayloadStreamHypeStarted{
+				Level:            ev.Level,
+				Total:            ev.Total,
+				Progress:         ev.Progress,
+				Goal:             ev.Goal,
+				TopContributions: fromHypeTrainContributions(ev.TopContributions),
+				ExpiresAt:        ev.ExpiresAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromHypeTrainProgressEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubHypeTrainProgressEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal HypeTrainProgressEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamHypeProgressed,
+		Payload: &Payload{
+			StreamHypeProgressed: &PayloadStreamHypeProgressed{
+				Level:            ev.Level,
+				Total:            ev.Total,
+				Progress:         ev.Progress,
+				Goal:             ev.Goal,
+				TopContributions: fromHypeTrainContributions(ev.TopContributions),
+				ExpiresAt:        ev.ExpiresAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromHypeTrainEndEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubHypeTrainEndEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal HypeTrainEndEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamHypeEnded,
+		Payload: &Payload{
+			StreamHypeEnded: &PayloadStreamHypeEnded{
+				Level:            ev.Level,
+				Total:            ev.Total,
+				TopContributions: fromHypeTrainContributions(ev.TopContributions),
+				CooldownEndsAt:   ev.CooldownEndsAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromHypeTrainContributions(contributions []helix.EventSubContribution) []HypeContribution {
+	result := make([]HypeContribution, 0, len(contributions))
+	for _, c := range contributions {
+		result = append(result, HypeContribution{
+			Viewer: core.Viewer{
+				TwitchUserId:      c.UserID,
+				TwitchDisplayName: c.UserName,
+			},
+			Type:  HypeContributionType(c.Type),
+			Total: int(c.Total),
+		})
+	}
+	return result
+}
+
+func fromChannelPollBeginEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubChannelPollBeginEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelPollBeginEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamPollStarted,
+		Payload: &Payload{
+			StreamPollStarted: &PayloadStreamPollStarted{
+				PollId:               ev.ID,
+				Title:                ev.Title,
+				Choices:              fromPollChoices(ev.Choices),
+				ChannelPointsPerVote: fromPollChannelPointsVoting(ev.ChannelPointsVoting),
+				StartedAt:            ev.StartedAt.Time,
+				EndsAt:               ev.EndsAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromChannelPollProgressEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubChannelPollProgressEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelPollProgressEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamPollProgressed,
+		Payload: &Payload{
+			StreamPollProgressed: &PayloadStreamPollProgressed{
+				PollId:               ev.ID,
+				Title:                ev.Title,
+				Choices:              fromPollChoices(ev.Choices),
+				ChannelPointsPerVote: fromPollChannelPointsVoting(ev.ChannelPointsVoting),
+				StartedAt:            ev.StartedAt.Time,
+				EndsAt:               ev.EndsAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromChannelPollEndEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubChannelPollEndEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelPollEndEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamPollEnded,
+		Payload: &Payload{
+			StreamPollEnded: &PayloadStreamPollEnded{
+				PollId:               ev.ID,
+				Title:                ev.Title,
+				Choices:              fromPollChoices(ev.Choices),
+				ChannelPointsPerVote: fromPollChannelPointsVoting(ev.ChannelPointsVoting),
+				Status:               PollStatus(ev.Status),
+				StartedAt:            ev.StartedAt.Time,
+				EndedAt:              ev.EndedAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromPollChoices(choices []helix.PollChoice) []core.PollChoice {
+	result := make([]core.PollChoice, 0, len(choices))
+	for _, c := range choices {
+		result = append(result, core.PollChoice{
+			Id:                    c.ID,
+			Title:                 c.Title,
+			NumVotes:              c.Votes,
+			NumChannelPointsVotes: c.ChannelPointsVotes,
+		})
+	}
+	return result
+}
+
+func fromPollChannelPointsVoting(voting helix.EventSubChannelPointsVoting) int {
+	if !voting.IsEnabled {
+		return 0
+	}
+	return voting.AmountPerVote
+}
+
+func fromChannelPredictionBeginEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubChannelPredictionBeginEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelPredictionBeginEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamPredictionStarted,
+		Payload: &Payload{
+			StreamPredictionStarted: &PayloadStreamPredictionStarted{
+				PredictionId: ev.ID,
+				Title:        ev.Title,
+				Outcomes:     fromPredictionOutcomes(ev.Outcomes),
+				StartedAt:    ev.StartedAt.Time,
+				LocksAt:      ev.LocksAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromChannelPredictionProgressEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubChannelPredictionProgressEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelPredictionProgressEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamPredictionProgressed,
+		Payload: &Payload{
+			StreamPredictionProgressed: &PayloadStreamPredictionProgressed{
+				PredictionId: ev.ID,
+				Title:        ev.Title,
+				Outcomes:     fromPredictionOutcomes(ev.Outcomes),
+				StartedAt:    ev.StartedAt.Time,
+				LocksAt:      ev.LocksAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromChannelPredictionLockEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubChannelPredictionLockEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelPredictionLockEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamPredictionLocked,
+		Payload: &Payload{
+			StreamPredictionLocked: &PayloadStreamPredictionLocked{
+				PredictionId: ev.ID,
+				Title:        ev.Title,
+				Outcomes:     fromPredictionOutcomes(ev.Outcomes),
+				StartedAt:    ev.StartedAt.Time,
+				LockedAt:     ev.LockedAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromChannelPredictionEndEvent(data json.RawMessage) (*Event, error) {
+	// helix.EventSubChannelPredictionEndEvent misspells the JSON key for ended_at
+	var ev struct {
+		helix.EventSubChannelPredictionEndEvent
+		EndedAt helix.Time `json:"ended_at"`
+	}
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelPredictionEndEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamPredictionEnded,
+		Payload: &Payload{
+			StreamPredictionEnded: &PayloadStreamPredictionEnded{
+				PredictionId:     ev.ID,
+				Title:            ev.Title,
+				Outcomes:         fromPredictionOutcomes(ev.Outcomes),
+				WinningOutcomeId: ev.WinningOutcomeID,
+				Status:           PredictionStatus(ev.Status),
+				StartedAt:        ev.StartedAt.Time,
+				EndedAt:          ev.EndedAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromPredictionOutcomes(outcomes []helix.EventSubOutcome) []PredictionOutcome {
+	result := make([]PredictionOutcome, 0, len(outcomes))
+	for _, o := range outcomes {
+		topPredictors := make([]Predictor, 0, len(o.TopPredictors))
+		for _, p := range o.TopPredictors {
+			topPredictors = append(topPredictors, Predictor{
+				Viewer: core.Viewer{
+					TwitchUserId:      p.UserID,
+					TwitchDisplayName: p.UserName,
+				},
+				ChannelPointsUsed: p.ChannelPointsUsed,
+				ChannelPointsWon:  p.ChannelPointWon,
+			})
+		}
+		result = append(result, PredictionOutcome{
+			Id:               o.ID,
+			Title:            o.Title,
+			Color:            PredictionOutcomeColor(o.Color),
+			NumUsers:         o.Users,
+			NumChannelPoints: o.ChannelPoints,
+			TopPredictors:    topPredictors,
+		})
+	}
+	return result
+}
+
+func fromChannelGoalBeginEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubChannelGoalStartEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelGoalBeginEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamGoalStarted,
+		Payload: &Payload{
+			StreamGoalStarted: &PayloadStreamGoalStarted{
+				GoalId:        ev.ID,
+				Type:          core.GoalType(ev.Type),
+				Description:   ev.Description,
+				CurrentAmount: ev.CurrentAmount,
+				TargetAmount:  ev.TargetAmount,
+				StartedAt:     ev.StartedAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromChannelGoalProgressEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubChannelGoalProgressEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelGoalProgressEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamGoalProgressed,
+		Payload: &Payload{
+			StreamGoalProgressed: &PayloadStreamGoalProgressed{
+				GoalId:        ev.ID,
+				Type:          core.GoalType(ev.Type),
+				Description:   ev.Description,
+				CurrentAmount: ev.CurrentAmount,
+				TargetAmount:  ev.TargetAmount,
+				StartedAt:     ev.StartedAt.Time,
+			},
+		},
+	}, nil
+}
+
+func fromChannelGoalEndEvent(data json.RawMessage) (*Event, error) {
+	var ev helix.EventSubChannelGoalEndEvent
+	if err := json.Unmarshal(data, &ev); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ChannelGoalEndEvent: %w", err)
+	}
+	return &Event{
+		Type: EventTypeStreamGoalEnded,
+		Payload: &Payload{
+			StreamGoalEnded: &PayloadStreamGoalEnded{
+				GoalId:        ev.ID,
+				Type:          core.GoalType(ev.Type),
+				Description:   ev.Description,
+				CurrentAmount: ev.CurrentAmount,
+				TargetAmount:  ev.TargetAmount,
+				IsAchieved:    ev.IsAchieved,
+				StartedAt:     ev.StartedAt.Time,
+				EndedAt:       ev.EndedAt.Time,
+			},
+		},
 	}, nil
 }
@@ -33,7 +33,12 @@ func Test_FromEventSub(t *testing.T) {
 			`{
 				"type": "stream-started",
 				"viewer": null,
-				"payload": null
+				"payload": {
+					"broadcaster_id": "1337",
+					"stream_id": "9001",
+					"stream_type": "live",
+					"started_at": "2020-10-11T10:11:12.123Z"
+				}
 			}`,
 		},
 		{
@@ -48,7 +53,9 @@ func Test_FromEventSub(t *testing.T) {
 			`{
 				"type": "stream-ended",
 				"viewer": null,
-				"payload": null
+				"payload": {
+					"broadcaster_id": "1337"
+				}
 			}`,
 		},
 		{
@@ -75,7 +82,651 @@ func Test_FromEventSub(t *testing.T) {
 			`{
 				"type": "stream-hype-started",
 				"viewer": null,
-				"payload": null
+				"payload": {
+					"level": 2,
+					"total": 137,
+					"progress": 137,
+					"goal": 500,
+					"top_contributions": [
+						{
+							"viewer": {
+								"twitch_user_id": "123",
+								"twitch_display_name": "PogChamp"
+							},
+							"type": "bits",
+							"total": 50
+						},
+						{
+							"viewer": {
+								"twitch_user_id": "456",
+								"twitch_display_name": "Kappa"
+							},
+							"type": "subscription",
+							"total": 45
+						}
+					],
+					"expires_at": "2020-07-15T17:16:11.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.hype_train.progress",
+			"",
+			`{
+				"id": "1b0AsbInCHZW2SQFQkCzqN07Ib2",
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cool_user",
+				"broadcaster_user_name": "Cool_User",
+				"level": 2,
+				"total": 700,
+				"progress": 200,
+				"goal": 1000,
+				"top_contributions": [
+					{ "user_id": "123", "user_login": "pogchamp", "user_name": "PogChamp", "type": "bits", "total": 50 },
+					{ "user_id": "456", "user_login": "kappa", "user_name": "Kappa", "type": "subscription", "total": 45 }
+				],
+				"last_contribution": { "user_id": "123", "user_login": "pogchamp", "user_name": "PogChamp", "type": "bits", "total": 50 },
+				"started_at": "2020-07-15T17:16:03.17106713Z",
+				"expires_at": "2020-07-15T17:16:11.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-hype-progressed",
+				"viewer": null,
+				"payload": {
+					"level": 2,
+					"total": 700,
+					"progress": 200,
+					"goal": 1000,
+					"top_contributions": [
+						{
+							"viewer": {
+								"twitch_user_id": "123",
+								"twitch_display_name": "PogChamp"
+							},
+							"type": "bits",
+							"total": 50
+						},
+						{
+							"viewer": {
+								"twitch_user_id": "456",
+								"twitch_display_name": "Kappa"
+							},
+							"type": "subscription",
+							"total": 45
+						}
+					],
+					"expires_at": "2020-07-15T17:16:11.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.hype_train.end",
+			"",
+			`{
+				"id": "1b0AsbInCHZW2SQFQkCzqN07Ib2",
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cool_user",
+				"broadcaster_user_name": "Cool_User",
+				"level": 2,
+				"total": 137,
+				"top_contributions": [
+					{ "user_id": "123", "user_login": "pogchamp", "user_name": "PogChamp", "type": "bits", "total": 50 },
+					{ "user_id": "456", "user_login": "kappa", "user_name": "Kappa", "type": "subscription", "total": 45 }
+				],
+				"started_at": "2020-07-15T17:16:03.17106713Z",
+				"ended_at": "2020-07-15T17:16:11.17106713Z",
+				"cooldown_ends_at": "2020-07-15T18:16:11.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-hype-ended",
+				"viewer": null,
+				"payload": {
+					"level": 2,
+					"total": 137,
+					"top_contributions": [
+						{
+							"viewer": {
+								"twitch_user_id": "123",
+								"twitch_display_name": "PogChamp"
+							},
+							"type": "bits",
+							"total": 50
+						},
+						{
+							"viewer": {
+								"twitch_user_id": "456",
+								"twitch_display_name": "Kappa"
+							},
+							"type": "subscription",
+							"total": 45
+						}
+					],
+					"cooldown_ends_at": "2020-07-15T18:16:11.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.poll.begin",
+			"",
+			`{
+				"id": "1243456",
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cool_user",
+				"broadcaster_user_name": "Cool_User",
+				"title": "Aren't shoes just really hard socks?",
+				"choices": [
+					{ "id": "123", "title": "Yeah!" },
+					{ "id": "124", "title": "No!" },
+					{ "id": "125", "title": "Maybe!" }
+				],
+				"bits_voting": { "is_enabled": true, "amount_per_vote": 10 },
+				"channel_points_voting": { "is_enabled": true, "amount_per_vote": 10 },
+				"started_at": "2020-07-15T17:16:03.17106713Z",
+				"ends_at": "2020-07-15T17:16:08.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-poll-started",
+				"viewer": null,
+				"payload": {
+					"poll_id": "1243456",
+					"title": "Aren't shoes just really hard socks?",
+					"choices": [
+						{
+							"id": "123",
+							"title": "Yeah!",
+							"num_votes": 0,
+							"num_channel_points_votes": 0
+						},
+						{
+							"id": "124",
+							"title": "No!",
+							"num_votes": 0,
+							"num_channel_points_votes": 0
+						},
+						{
+							"id": "125",
+							"title": "Maybe!",
+							"num_votes": 0,
+							"num_channel_points_votes": 0
+						}
+					],
+					"channel_points_per_vote": 10,
+					"started_at": "2020-07-15T17:16:03.17106713Z",
+					"ends_at": "2020-07-15T17:16:08.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.poll.progress",
+			"",
+			`{
+				"id": "1243456",
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cool_user",
+				"broadcaster_user_name": "Cool_User",
+				"title": "Aren't shoes just really hard socks?",
+				"choices": [
+					{ "id": "123", "title": "Yeah!", "bits_votes": 5, "channel_points_votes": 7, "votes": 12 },
+					{ "id": "124", "title": "No!", "bits_votes": 10, "channel_points_votes": 4, "votes": 14 },
+					{ "id": "125", "title": "Maybe!", "bits_votes": 0, "channel_points_votes": 7, "votes": 7 }
+				],
+				"bits_voting": { "is_enabled": true, "amount_per_vote": 10 },
+				"channel_points_voting": { "is_enabled": false, "amount_per_vote": 0 },
+				"started_at": "2020-07-15T17:16:03.17106713Z",
+				"ends_at": "2020-07-15T17:16:08.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-poll-progressed",
+				"viewer": null,
+				"payload": {
+					"poll_id": "1243456",
+					"title": "Aren't shoes just really hard socks?",
+					"choices": [
+						{
+							"id": "123",
+							"title": "Yeah!",
+							"num_votes": 12,
+							"num_channel_points_votes": 7
+						},
+						{
+							"id": "124",
+							"title": "No!",
+							"num_votes": 14,
+							"num_channel_points_votes": 4
+						},
+						{
+							"id": "125",
+							"title": "Maybe!",
+							"num_votes": 7,
+							"num_channel_points_votes": 7
+						}
+					],
+					"channel_points_per_vote": 0,
+					"started_at": "2020-07-15T17:16:03.17106713Z",
+					"ends_at": "2020-07-15T17:16:08.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.poll.end",
+			"",
+			`{
+				"id": "1243456",
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cool_user",
+				"broadcaster_user_name": "Cool_User",
+				"title": "Aren't shoes just really hard socks?",
+				"choices": [
+					{ "id": "123", "title": "Blue", "bits_votes": 50, "channel_points_votes": 70, "votes": 120 },
+					{ "id": "124", "title": "Yellow", "bits_votes": 100, "channel_points_votes": 40, "votes": 140 },
+					{ "id": "125", "title": "Green", "bits_votes": 10, "channel_points_votes": 70, "votes": 80 }
+				],
+				"bits_voting": { "is_enabled": true, "amount_per_vote": 10 },
+				"channel_points_voting": { "is_enabled": true, "amount_per_vote": 10 },
+				"status": "completed",
+				"started_at": "2020-07-15T17:16:03.17106713Z",
+				"ended_at": "2020-07-15T17:16:11.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-poll-ended",
+				"viewer": null,
+				"payload": {
+					"poll_id": "1243456",
+					"title": "Aren't shoes just really hard socks?",
+					"choices": [
+						{
+							"id": "123",
+							"title": "Blue",
+							"num_votes": 120,
+							"num_channel_points_votes": 70
+						},
+						{
+							"id": "124",
+							"title": "Yellow",
+							"num_votes": 140,
+							"num_channel_points_votes": 40
+						},
+						{
+							"id": "125",
+							"title": "Green",
+							"num_votes": 80,
+							"num_channel_points_votes": 70
+						}
+					],
+					"channel_points_per_vote": 10,
+					"status": "completed",
+					"started_at": "2020-07-15T17:16:03.17106713Z",
+					"ended_at": "2020-07-15T17:16:11.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.prediction.begin",
+			"",
+			`{
+				"id": "1243456",
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cool_user",
+				"broadcaster_user_name": "Cool_User",
+				"title": "Aren't shoes just really hard socks?",
+				"outcomes": [
+					{ "id": "1243456", "title": "Yeah!", "color": "blue" },
+					{ "id": "9876543", "title": "No!", "color": "pink" }
+				],
+				"started_at": "2020-07-15T17:16:03.17106713Z",
+				"locks_at": "2020-07-15T17:21:03.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-prediction-started",
+				"viewer": null,
+				"payload": {
+					"prediction_id": "1243456",
+					"title": "Aren't shoes just really hard socks?",
+					"outcomes": [
+						{
+							"id": "1243456",
+							"title": "Yeah!",
+							"color": "blue",
+							"num_users": 0,
+							"num_channel_points": 0,
+							"top_predictors": []
+						},
+						{
+							"id": "9876543",
+							"title": "No!",
+							"color": "pink",
+							"num_users": 0,
+							"num_channel_points": 0,
+							"top_predictors": []
+						}
+					],
+					"started_at": "2020-07-15T17:16:03.17106713Z",
+					"locks_at": "2020-07-15T17:21:03.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.prediction.lock",
+			"",
+			`{
+				"id": "1243456",
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cool_user",
+				"broadcaster_user_name": "Cool_User",
+				"title": "Aren't shoes just really hard socks?",
+				"outcomes": [
+					{
+						"id": "1243456",
+						"title": "Yeah!",
+						"color": "blue",
+						"users": 10,
+						"channel_points": 15000,
+						"top_predictors": [
+							{ "user_name": "Cool_User", "user_login": "cool_user", "user_id": "1234", "channel_points_won": null, "channel_points_used": 500 },
+							{ "user_name": "Coolest_User", "user_login": "coolest_user", "user_id": "1236", "channel_points_won": null, "channel_points_used": 200 }
+						]
+					},
+					{
+						"id": "2",
+						"title": "No!",
+						"color": "pink",
+						"users": 0,
+						"channel_points": 0,
+						"top_predictors": []
+					}
+				],
+				"started_at": "2020-07-15T17:16:03.17106713Z",
+				"locked_at": "2020-07-15T17:21:03.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-prediction-locked",
+				"viewer": null,
+				"payload": {
+					"prediction_id": "1243456",
+					"title": "Aren't shoes just really hard socks?",
+					"outcomes": [
+						{
+							"id": "1243456",
+							"title": "Yeah!",
+							"color": "blue",
+							"num_users": 10,
+							"num_channel_points": 15000,
+							"top_predictors": [
+								{
+									"viewer": {
+										"twitch_user_id": "1234",
+										"twitch_display_name": "Cool_User"
+									},
+									"channel_points_used": 500,
+									"channel_points_won": 0
+								},
+								{
+									"viewer": {
+										"twitch_user_id": "1236",
+										"twitch_display_name": "Coolest_User"
+									},
+									"channel_points_used": 200,
+									"channel_points_won": 0
+								}
+							]
+						},
+						{
+							"id": "2",
+							"title": "No!",
+							"color": "pink",
+							"num_users": 0,
+							"num_channel_points": 0,
+							"top_predictors": []
+						}
+					],
+					"started_at": "2020-07-15T17:16:03.17106713Z",
+					"locked_at": "2020-07-15T17:21:03.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.prediction.end",
+			"",
+			`{
+				"id": "1243456",
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cool_user",
+				"broadcaster_user_name": "Cool_User",
+				"title": "Aren't shoes just really hard socks?",
+				"winning_outcome_id": "12345",
+				"outcomes": [
+					{
+						"id": "12345",
+						"title": "Yeah!",
+						"color": "blue",
+						"users": 2,
+						"channel_points": 15000,
+						"top_predictors": [
+							{ "user_name": "Cool_User", "user_login": "cool_user", "user_id": "1234", "channel_points_won": 10000, "channel_points_used": 500 },
+							{ "user_name": "Coolest_User", "user_login": "coolest_user", "user_id": "1236", "channel_points_won": 5000, "channel_points_used": 100 }
+						]
+					},
+					{
+						"id": "22435",
+						"title": "No!",
+						"color": "pink",
+						"users": 1,
+						"channel_points": 200,
+						"top_predictors": [
+							{ "user_name": "Cooler_User", "user_login": "cooler_user", "user_id": "12345", "channel_points_won": null, "channel_points_used": 200 }
+						]
+					}
+				],
+				"status": "resolved",
+				"started_at": "2020-07-15T17:16:03.17106713Z",
+				"ended_at": "2020-07-15T17:16:11.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-prediction-ended",
+				"viewer": null,
+				"payload": {
+					"prediction_id": "1243456",
+					"title": "Aren't shoes just really hard socks?",
+					"outcomes": [
+						{
+							"id": "12345",
+							"title": "Yeah!",
+							"color": "blue",
+							"num_users": 2,
+							"num_channel_points": 15000,
+							"top_predictors": [
+								{
+									"viewer": {
+										"twitch_user_id": "1234",
+										"twitch_display_name": "Cool_User"
+									},
+									"channel_points_used": 500,
+									"channel_points_won": 10000
+								},
+								{
+									"viewer": {
+										"twitch_user_id": "1236",
+										"twitch_display_name": "Coolest_User"
+									},
+									"channel_points_used": 100,
+									"channel_points_won": 5000
+								}
+							]
+						},
+						{
+							"id": "22435",
+							"title": "No!",
+							"color": "pink",
+							"num_users": 1,
+							"num_channel_points": 200,
+							"top_predictors": [
+								{
+									"viewer": {
+										"twitch_user_id": "12345",
+										"twitch_display_name": "Cooler_User"
+									},
+									"channel_points_used": 200,
+									"channel_points_won": 0
+								}
+							]
+						}
+					],
+					"winning_outcome_id": "12345",
+					"status": "resolved",
+					"started_at": "2020-07-15T17:16:03.17106713Z",
+					"ended_at": "2020-07-15T17:16:11.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.goal.begin",
+			"",
+			`{
+				"id": "12345-cool-event",
+				"broadcaster_user_id": "141981764",
+				"broadcaster_user_name": "TwitchDev",
+				"broadcaster_user_login": "twitchdev",
+				"type": "subscription",
+				"description": "Help me get partner!",
+				"current_amount": 100,
+				"target_amount": 220,
+				"started_at": "2021-07-15T17:16:03.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-goal-started",
+				"viewer": null,
+				"payload": {
+					"goal_id": "12345-cool-event",
+					"type": "subscription",
+					"description": "Help me get partner!",
+					"current_amount": 100,
+					"target_amount": 220,
+					"started_at": "2021-07-15T17:16:03.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.goal.progress",
+			"",
+			`{
+				"id": "12345-cool-event",
+				"broadcaster_user_id": "141981764",
+				"broadcaster_user_name": "TwitchDev",
+				"broadcaster_user_login": "twitchdev",
+				"type": "follow",
+				"description": "Road to 500 followers",
+				"current_amount": 120,
+				"target_amount": 500,
+				"started_at": "2021-07-15T17:16:03.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-goal-progressed",
+				"viewer": null,
+				"payload": {
+					"goal_id": "12345-cool-event",
+					"type": "follow",
+					"description": "Road to 500 followers",
+					"current_amount": 120,
+					"target_amount": 500,
+					"started_at": "2021-07-15T17:16:03.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.goal.end",
+			"",
+			`{
+				"id": "12345-abc-678-defgh",
+				"broadcaster_user_id": "141981764",
+				"broadcaster_user_name": "TwitchDev",
+				"broadcaster_user_login": "twitchdev",
+				"type": "subscription",
+				"description": "Help me get partner!",
+				"is_achieved": false,
+				"current_amount": 180,
+				"target_amount": 220,
+				"started_at": "2021-07-15T17:16:03.17106713Z",
+				"ended_at": "2021-07-16T17:16:03.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-goal-ended",
+				"viewer": null,
+				"payload": {
+					"goal_id": "12345-abc-678-defgh",
+					"type": "subscription",
+					"description": "Help me get partner!",
+					"current_amount": 180,
+					"target_amount": 220,
+					"is_achieved": false,
+					"started_at": "2021-07-15T17:16:03.17106713Z",
+					"ended_at": "2021-07-16T17:16:03.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.update",
+			"",
+			`{
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cool_user",
+				"broadcaster_user_name": "Cool_User",
+				"title": "Best Stream Ever",
+				"language": "en",
+				"category_id": "12453",
+				"category_name": "Grand Theft Auto",
+				"content_classification_labels": [ "MatureGame" ]
+			}`,
+			nil,
+			`{
+				"type": "stream-updated",
+				"viewer": null,
+				"payload": {
+					"broadcaster_id": "1337",
+					"title": "Best Stream Ever",
+					"category_id": "12453",
+					"category_name": "Grand Theft Auto",
+					"language": "en",
+					"content_labels": [
+						"MatureGame"
+					]
+				}
+			}`,
+		},
+		{
+			"channel.update",
+			"unclassified",
+			`{
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cool_user",
+				"broadcaster_user_name": "Cool_User",
+				"title": "Best Stream Ever",
+				"language": "en",
+				"category_id": "",
+				"category_name": ""
+			}`,
+			nil,
+			`{
+				"type": "stream-updated",
+				"viewer": null,
+				"payload": {
+					"broadcaster_id": "1337",
+					"title": "Best Stream Ever",
+					"category_id": "",
+					"category_name": "",
+					"language": "en",
+					"content_labels": []
+				}
 			}`,
 		},
 		{
@@ -135,7 +786,7 @@ func Test_FromEventSub(t *testing.T) {
 				"broadcaster_user_id": "1337",
 				"broadcaster_user_login": "cooler_user",
 				"broadcaster_user_name": "Cooler_User",
-				"message": "pogchamp",
+				"message": "Cheer600 pogchamp Cheer400",
 				"bits": 1000
 			}`,
 			nil,
@@ -147,7 +798,33 @@ func Test_FromEventSub(t *testing.T) {
 				},
 				"payload": {
 					"num_bits": 1000,
-					"message": "pogchamp"
+					"message": "Cheer600 pogchamp Cheer400",
+					"message_fragments": [
+						{
+							"type": "cheermote",
+							"text": "Cheer600",
+							"emote": null,
+							"cheermote": {
+								"prefix": "Cheer",
+								"num_bits": 600
+							}
+						},
+						{
+							"type": "text",
+							"text": " pogchamp ",
+							"emote": null,
+							"cheermote": null
+						},
+						{
+							"type": "cheermote",
+							"text": "Cheer400",
+							"emote": null,
+							"cheermote": {
+								"prefix": "Cheer",
+								"num_bits": 400
+							}
+						}
+					]
 				}
 			}`,
 		},
@@ -171,6 +848,50 @@ func Test_FromEventSub(t *testing.T) {
 				"viewer": null,
 				"payload": {
 					"num_bits": 1000,
+					"message": "pogchamp",
+					"message_fragments": [
+						{
+							"type": "text",
+							"text": "pogchamp",
+							"emote": null,
+							"cheermote": null
+						}
+					]
+				}
+			}`,
+		},
+		{
+			"channel.channel_points_custom_reward_redemption.add",
+			"",
+			`{
+				"id": "17fa2df1-ad76-4804-bfa5-a40ef63efe63",
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cool_user",
+				"broadcaster_user_name": "Cool_User",
+				"user_id": "9001",
+				"user_login": "cooler_user",
+				"user_name": "Cooler_User",
+				"user_input": "pogchamp",
+				"status": "unfulfilled",
+				"reward": {
+					"id": "92af127c-7326-4483-a52b-b0da0be61c01",
+					"title": "title",
+					"cost": 100,
+					"prompt": "reward prompt"
+				},
+				"redeemed_at": "2020-07-15T17:16:03.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "viewer-redeemed-reward",
+				"viewer": {
+					"twitch_user_id": "9001",
+					"twitch_display_name": "Cooler_User"
+				},
+				"payload": {
+					"reward_id": "92af127c-7326-4483-a52b-b0da0be61c01",
+					"reward_title": "title",
+					"reward_cost": 100,
 					"message": "pogchamp"
 				}
 			}`,
@@ -196,6 +917,7 @@ func Test_FromEventSub(t *testing.T) {
 					"twitch_display_name": "Cool_User"
 				},
 				"payload": {
+					"tier": "1000",
 					"credit_multiplier": 1
 				}
 			}`,
@@ -221,6 +943,7 @@ func Test_FromEventSub(t *testing.T) {
 					"twitch_display_name": "Cool_User"
 				},
 				"payload": {
+					"tier": "2000",
 					"credit_multiplier": 2
 				}
 			}`,
@@ -246,6 +969,7 @@ func Test_FromEventSub(t *testing.T) {
 					"twitch_display_name": "Cool_User"
 				},
 				"payload": {
+					"tier": "1000",
 					"credit_multiplier": 1
 				}
 			}`,
@@ -271,6 +995,7 @@ func Test_FromEventSub(t *testing.T) {
 					"twitch_display_name": "Cool_User"
 				},
 				"payload": {
+					"tier": "3000",
 					"credit_multiplier": 5
 				}
 			}`,
@@ -290,8 +1015,8 @@ func Test_FromEventSub(t *testing.T) {
 					"text": "Love the stream! FevziGG",
 					"emotes": [
 						{
-							"begin": 23,
-							"end": 30,
+							"begin": 17,
+							"end": 23,
 							"id": "302976485"
 						}
 					]
@@ -308,9 +1033,26 @@ func Test_FromEventSub(t *testing.T) {
 					"twitch_display_name": "Cool_User"
 				},
 				"payload": {
+					"tier": "2000",
 					"credit_multiplier": 2,
 					"num_cumulative_months": 15,
-					"message": "Love the stream! FevziGG"
+					"message": "Love the stream! FevziGG",
+					"message_fragments": [
+						{
+							"type": "text",
+							"text": "Love the stream! ",
+							"emote": null,
+							"cheermote": null
+						},
+						{
+							"type": "emote",
+							"text": "FevziGG",
+							"emote": {
+								"id": "302976485"
+							},
+							"cheermote": null
+						}
+					]
 				}
 			}`,
 		},
@@ -337,6 +1079,7 @@ func Test_FromEventSub(t *testing.T) {
 					"twitch_display_name": "Cool_User"
 				},
 				"payload": {
+					"tier": "3000",
 					"credit_multiplier": 5,
 					"num_subscriptions": 2
 				}
@@ -362,11 +1105,200 @@ func Test_FromEventSub(t *testing.T) {
 				"type": "viewer-gifted-subs",
 				"viewer": null,
 				"payload": {
+					"tier": "1000",
 					"credit_multiplier": 1,
 					"num_subscriptions": 2
 				}
 			}`,
 		},
+		{
+			"channel.shoutout.create",
+			"",
+			`{
+				"broadcaster_user_id": "12345",
+				"broadcaster_user_name": "SimplySimple",
+				"broadcaster_user_login": "simplysimple",
+				"moderator_user_id": "98765",
+				"moderator_user_name": "ParticularlyParticular123",
+				"moderator_user_login": "particularlyparticular123",
+				"to_broadcaster_user_id": "626262",
+				"to_broadcaster_user_name": "SandySanderman",
+				"to_broadcaster_user_login": "sandysanderman",
+				"started_at": "2022-07-26T17:00:03.17106713Z",
+				"viewer_count": 860,
+				"cooldown_ends_at": "2022-07-26T17:02:03.17106713Z",
+				"target_cooldown_ends_at": "2022-07-26T18:00:03.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-shoutout-given",
+				"viewer": null,
+				"payload": {
+					"broadcaster": {
+						"twitch_user_id": "626262",
+						"twitch_display_name": "SandySanderman"
+					},
+					"moderator": {
+						"twitch_user_id": "98765",
+						"twitch_display_name": "ParticularlyParticular123"
+					},
+					"num_viewers": 860,
+					"started_at": "2022-07-26T17:00:03.17106713Z",
+					"cooldown_ends_at": "2022-07-26T17:02:03.17106713Z",
+					"target_cooldown_ends_at": "2022-07-26T18:00:03.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.shoutout.receive",
+			"",
+			`{
+				"broadcaster_user_id": "626262",
+				"broadcaster_user_name": "SandySanderman",
+				"broadcaster_user_login": "sandysanderman",
+				"from_broadcaster_user_id": "12345",
+				"from_broadcaster_user_name": "SimplySimple",
+				"from_broadcaster_user_login": "simplysimple",
+				"viewer_count": 860,
+				"started_at": "2022-07-26T17:00:03.17106713Z"
+			}`,
+			nil,
+			`{
+				"type": "stream-shoutout-received",
+				"viewer": null,
+				"payload": {
+					"broadcaster": {
+						"twitch_user_id": "12345",
+						"twitch_display_name": "SimplySimple"
+					},
+					"num_viewers": 860,
+					"started_at": "2022-07-26T17:00:03.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.ban",
+			"",
+			`{
+				"user_id": "1234",
+				"user_login": "cool_user",
+				"user_name": "Cool_User",
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cooler_user",
+				"broadcaster_user_name": "Cooler_User",
+				"moderator_user_id": "1339",
+				"moderator_user_login": "mod_user",
+				"moderator_user_name": "Mod_User",
+				"reason": "Offensive language",
+				"banned_at": "2020-07-15T18:15:11.17106713Z",
+				"ends_at": "2020-07-15T18:16:11.17106713Z",
+				"is_permanent": false
+			}`,
+			nil,
+			`{
+				"type": "viewer-banned",
+				"viewer": {
+					"twitch_user_id": "1234",
+					"twitch_display_name": "Cool_User"
+				},
+				"payload": {
+					"moderator": {
+						"twitch_user_id": "1339",
+						"twitch_display_name": "Mod_User"
+					},
+					"reason": "Offensive language",
+					"expires_at": "2020-07-15T18:16:11.17106713Z"
+				}
+			}`,
+		},
+		{
+			"channel.ban",
+			"permanent",
+			`{
+				"user_id": "1234",
+				"user_login": "cool_user",
+				"user_name": "Cool_User",
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cooler_user",
+				"broadcaster_user_name": "Cooler_User",
+				"moderator_user_id": "1339",
+				"moderator_user_login": "mod_user",
+				"moderator_user_name": "Mod_User",
+				"reason": "",
+				"banned_at": "2020-07-15T18:15:11.17106713Z",
+				"ends_at": null,
+				"is_permanent": true
+			}`,
+			nil,
+			`{
+				"type": "viewer-banned",
+				"viewer": {
+					"twitch_user_id": "1234",
+					"twitch_display_name": "Cool_User"
+				},
+				"payload": {
+					"moderator": {
+						"twitch_user_id": "1339",
+						"twitch_display_name": "Mod_User"
+					},
+					"reason": "",
+					"expires_at": null
+				}
+			}`,
+		},
+		{
+			"channel.unban",
+			"",
+			`{
+				"user_id": "1234",
+				"user_login": "cool_user",
+				"user_name": "Cool_User",
+				"broadcaster_user_id": "1337",
+				"broadcaster_user_login": "cooler_user",
+				"broadcaster_user_name": "Cooler_User",
+				"moderator_user_id": "1339",
+				"moderator_user_login": "mod_user",
+				"moderator_user_name": "Mod_User"
+			}`,
+			nil,
+			`{
+				"type": "viewer-unbanned",
+				"viewer": {
+					"twitch_user_id": "1234",
+					"twitch_display_name": "Cool_User"
+				},
+				"payload": {
+					"moderator": {
+						"twitch_user_id": "1339",
+						"twitch_display_name": "Mod_User"
+					}
+				}
+			}`,
+		},
+		{
+			"channel.chat.message_delete",
+			"",
+			`{
+				"broadcaster_user_id": "1971641",
+				"broadcaster_user_name": "StreamerBot",
+				"broadcaster_user_login": "streamerbot",
+				"target_user_id": "7734",
+				"target_user_name": "Uneasy_Seal",
+				"target_user_login": "uneasy_seal",
+				"message_id": "ab24e0b0-2260-4bac-94e4-05eedd4ecd0e"
+			}`,
+			nil,
+			`{
+				"type": "viewer-message-deleted",
+				"viewer": {
+					"twitch_user_id": "7734",
+					"twitch_display_name": "Uneasy_Seal"
+				},
+				"payload": {
+					"message_id": "ab24e0b0-2260-4bac-94e4-05eedd4ecd0e"
+				}
+			}`,
+		},
 	}
 	for _, tt := range tests {
 		name := tt.subscriptionType
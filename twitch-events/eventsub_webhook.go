@@ -0,0 +1,217 @@
+package etwitch
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nicklaw5/helix/v2"
+)
+
+// Headers included in every EventSub webhook request, as described in:
+// https://dev.twitch.tv/docs/eventsub/handling-webhook-events
+const (
+	EventSubHeaderMessageId        = "Twitch-Eventsub-Message-Id"
+	EventSubHeaderMessageTimestamp = "Twitch-Eventsub-Message-Timestamp"
+	EventSubHeaderMessageSignature = "Twitch-Eventsub-Message-Signature"
+	EventSubHeaderMessageType      = "Twitch-Eventsub-Message-Type"
+)
+
+// EventSubMessageType identifies the purpose of an EventSub webhook request
+type EventSubMessageType string
+
+const (
+	EventSubMessageTypeNotification EventSubMessageType = "notification"
+	EventSubMessageTypeVerification EventSubMessageType = "webhook_callback_verification"
+	EventSubMessageTypeRevocation   EventSubMessageType = "revocation"
+)
+
+// DefaultEventSubMaxAge is the age beyond which EventSub messages are rejected, as
+// recommended by Twitch, in order to prevent replay attacks
+const DefaultEventSubMaxAge = 10 * time.Minute
+
+// DefaultEventSubMaxClockSkew is how far in the future an EventSub message's timestamp
+// may be before the message is rejected, allowing for minor disagreement between our
+// clock and Twitch's
+const DefaultEventSubMaxClockSkew = time.Minute
+
+// maxEventSubBodySize limits the size of the request body that we'll read
+const maxEventSubBodySize = 1 << 20
+
+var (
+	ErrInvalidEventSubSignature   = errors.New("invalid EventSub message signature")
+	ErrStaleEventSubMessage       = errors.New("EventSub message is too old")
+	ErrFutureEventSubMessage      = errors.New("EventSub message timestamp is in the future")
+	ErrUnsupportedEventSubMessage = errors.New("unsupported EventSub message type")
+)
+
+// EventSubMessage is a message received from EventSub, either as a verified webhook
+// request or via the WebSocket transport
+type EventSubMessage struct {
+	// Id uniquely identifies the message; Twitch may deliver the same message more than
+	// once
+	Id string
+	// Type identifies the purpose of the message
+	Type EventSubMessageType
+	// Timestamp records when Twitch sent the message
+	Timestamp time.Time
+	// Subscription describes the subscription that the message pertains to: for
+	// revocations, its Status explains why the subscription was revoked
+	Subscription helix.EventSubSubscription
+	// Challenge is the value that must be echoed back in response to a verification
+	// message, in order to confirm the subscription
+	Challenge string
+	// SessionId identifies the WebSocket session that was established by a welcome
+	// message: it must be supplied when creating subscriptions for that session
+	SessionId string
+	// Event is the result of converting a notification's event to our own schema: it's
+	// nil for other types of message, for notifications from subscriptions that we
+	// don't convert, and for notifications whose event could not be converted
+	Event *Event
+	// ConversionErr explains why a notification's event could not be converted, if
+	// conversion failed: the message is still delivered, so that a single malformed
+	// event doesn't prevent subsequent messages from being handled
+	ConversionErr error
+}
+
+// EventSubWebhook verifies and decodes the requests that Twitch makes to an EventSub
+// webhook callback URL
+type EventSubWebhook struct {
+	secret  []byte
+	maxAge  time.Duration
+	maxSkew time.Duration
+	now     func() time.Time
+}
+
+// NewEventSubWebhook initializes an EventSubWebhook that verifies requests using the
+// secret that was supplied when creating the corresponding EventSub subscriptions
+func NewEventSubWebhook(secret string) *EventSubWebhook {
+	return &EventSubWebhook{
+		secret:  []byte(secret),
+		maxAge:  DefaultEventSubMaxAge,
+		maxSkew: DefaultEventSubMaxClockSkew,
+		now:     time.Now,
+	}
+}
+
+// ReadRequest reads an EventSub webhook request, verifying its signature and rejecting
+// it if its timestamp is stale, then decodes its body according to its message type. If
+// the signature is invalid, ErrInvalidEventSubSignature is returned; if the message is
+// too old, ErrStaleEventSubMessage is returned; if its timestamp is further in the
+// future than DefaultEventSubMaxClockSkew allows, ErrFutureEventSubMessage is returned.
+// A notification whose event can't be converted is returned with its ConversionErr set.
+func (w *EventSubWebhook) ReadRequest(r *http.Request) (*EventSubMessage, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxEventSubBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read EventSub message: %w", err)
+	}
+	if len(body) > maxEventSubBodySize {
+		return nil, fmt.Errorf("EventSub message exceeds %d bytes", maxEventSubBodySize)
+	}
+
+	id := r.Header.Get(EventSubHeaderMessageId)
+	timestamp := r.Header.Get(EventSubHeaderMessageTimestamp)
+	if !w.verifySignature(id, timestamp, body, r.Header.Get(EventSubHeaderMessageSignature)) {
+		return nil, ErrInvalidEventSubSignature
+	}
+	sentAt, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse EventSub message timestamp: %w", err)
+	}
+	age := w.now().Sub(sentAt)
+	if age > w.maxAge {
+		return nil, fmt.Errorf("%w: sent at %s", ErrStaleEventSubMessage, timestamp)
+	}
+	if age < -w.maxSkew {
+		return nil, fmt.Errorf("%w: sent at %s", ErrFutureEventSubMessage, timestamp)
+	}
+
+	var payload struct {
+		Subscription helix.EventSubSubscription `json:"subscription"`
+		Challenge    string                     `json:"challenge"`
+		Event        json.RawMessage            `json:"event"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal EventSub message: %w", err)
+	}
+	message := &EventSubMessage{
+		Id:           id,
+		Type:         EventSubMessageType(r.Header.Get(EventSubHeaderMessageType)),
+		Timestamp:    sentAt,
+		Subscription: payload.Subscription,
+	}
+	switch message.Type {
+	case EventSubMessageTypeNotification:
+		ev, err := FromEventSub(&payload.Subscription, payload.Event)
+		if err != nil && !errors.Is(err, ErrUnsupportedEventSubType) {
+			message.ConversionErr = fmt.Errorf("failed to convert %s event: %w", payload.Subscription.Type, err)
+		}
+		if ev != nil {
+			ev.EventSubMessageId = id
+		}
+		message.Event = ev
+	case EventSubMessageTypeVerification:
+		message.Challenge = payload.Challenge
+	case EventSubMessageTypeRevocation:
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedEventSubMessage, message.Type)
+	}
+	return message, nil
+}
+
+func (w *EventSubWebhook) verifySignature(id string, timestamp string, body []byte, signature string) bool {
+	hexDigest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	digest, err := hex.DecodeString(hexDigest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, w.secret)
+	mac.Write([]byte(id))
+	mac.Write([]byte(timestamp))
+	mac.Write(body)
+	return hmac.Equal(digest, mac.Sum(nil))
+}
+
+// Handler returns an http.Handler that serves an EventSub webhook callback URL. It
+// answers verification challenges itself, and it calls handle with every notification
+// and revocation: if handle returns an error, Twitch is sent a 500 response, prompting
+// it to retry the notification later. Notifications whose event can't be converted are
+// passed to handle with their ConversionErr set, and are acknowledged unless handle
+// fails, since redelivering them would only fail again. Requests that can't be verified
+// are rejected with a 403 response.
+func (w *EventSubWebhook) Handler(handle func(ctx context.Context, message *EventSubMessage) error) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		message, err := w.ReadRequest(req)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, ErrInvalidEventSubSignature) || errors.Is(err, ErrStaleEventSubMessage) || errors.Is(err, ErrFutureEventSubMessage) {
+				status = http.StatusForbidden
+			}
+			http.Error(res, err.Error(), status)
+			return
+		}
+
+		if message.Type == EventSubMessageTypeVerification {
+			res.Header().Set("content-type", "text/plain")
+			res.WriteHeader(http.StatusOK)
+			res.Write([]byte(message.Challenge))
+			return
+		}
+		if err := handle(req.Context(), message); err != nil {
+			http.Error(res, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		res.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,274 @@
+package etwitch
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/stretchr/testify/assert"
+)
+
+const testWebhookSecret = "s3cre7-s3cre7-s3cre7"
+
+var testWebhookNow = time.Date(2023, 7, 19, 14, 56, 51, 634234626, time.UTC)
+
+// newSignedRequest builds an EventSub webhook request, signed with the given secret as
+// Twitch would sign it
+func newSignedRequest(secret string, messageType string, timestamp time.Time, body string) *http.Request {
+	id := "e76c6bd4-55c9-4987-8304-da1588d8988b"
+	ts := timestamp.Format(time.RFC3339Nano)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(id + ts + body))
+
+	r := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body))
+	r.Header.Set(EventSubHeaderMessageId, id)
+	r.Header.Set(EventSubHeaderMessageTimestamp, ts)
+	r.Header.Set(EventSubHeaderMessageSignature, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	r.Header.Set(EventSubHeaderMessageType, messageType)
+	return r
+}
+
+func newTestWebhook() *EventSubWebhook {
+	w := NewEventSubWebhook(testWebhookSecret)
+	w.now = func() time.Time { return testWebhookNow }
+	return w
+}
+
+func Test_EventSubWebhook_ReadRequest(t *testing.T) {
+	// Test EventSub messages are adapted from:
+	// https://dev.twitch.tv/docs/eventsub/handling-webhook-events
+	tests := []struct {
+		name          string
+		r             *http.Request
+		wantErr       error
+		wantType      EventSubMessageType
+		wantStatus    string
+		wantChallenge string
+		wantEvent     *Event
+	}{
+		{
+			"verification challenge",
+			newSignedRequest(testWebhookSecret, "webhook_callback_verification", testWebhookNow, `{
+				"challenge": "pogchamp-kappa-360noscope-vohiyo",
+				"subscription": {
+					"id": "f1c2a387-161a-49f9-a165-0f21d7a4e1c4",
+					"status": "webhook_callback_verification_pending",
+					"type": "channel.follow",
+					"version": "2"
+				}
+			}`),
+			nil,
+			EventSubMessageTypeVerification,
+			"webhook_callback_verification_pending",
+			"pogchamp-kappa-360noscope-vohiyo",
+			nil,
+		},
+		{
+			"notification",
+			newSignedRequest(testWebhookSecret, "notification", testWebhookNow.Add(-time.Minute), `{
+				"subscription": {
+					"id": "f1c2a387-161a-49f9-a165-0f21d7a4e1c4",
+					"status": "enabled",
+					"type": "channel.follow",
+					"version": "2"
+				},
+				"event": {
+					"user_id": "1234",
+					"user_login": "cool_user",
+					"user_name": "Cool_User",
+					"broadcaster_user_id": "1337",
+					"broadcaster_user_login": "cooler_user",
+					"broadcaster_user_name": "Cooler_User",
+					"followed_at": "2020-07-15T18:16:11.17106713Z"
+				}
+			}`),
+			nil,
+			EventSubMessageTypeNotification,
+			"enabled",
+			"",
+			&Event{
+				Type:              EventTypeViewerFollowed,
+				Viewer:            &core.Viewer{TwitchUserId: "1234", TwitchDisplayName: "Cool_User"},
+				EventSubMessageId: "e76c6bd4-55c9-4987-8304-da1588d8988b",
+			},
+		},
+		{
+			"notification from unsupported subscription type",
+			newSignedRequest(testWebhookSecret, "notification", testWebhookNow, `{
+				"subscription": {
+					"id": "f1c2a387-161a-49f9-a165-0f21d7a4e1c4",
+					"status": "enabled",
+					"type": "channel.shield_mode.begin",
+					"version": "1"
+				},
+				"event": {}
+			}`),
+			nil,
+			EventSubMessageTypeNotification,
+			"enabled",
+			"",
+			nil,
+		},
+		{
+			"revocation",
+			newSignedRequest(testWebhookSecret, "revocation", testWebhookNow, `{
+				"subscription": {
+					"id": "f1c2a387-161a-49f9-a165-0f21d7a4e1c4",
+					"status": "authorization_revoked",
+					"type": "channel.follow",
+					"version": "2"
+				}
+			}`),
+			nil,
+			EventSubMessageTypeRevocation,
+			"authorization_revoked",
+			"",
+			nil,
+		},
+		{
+			"invalid signature",
+			newSignedRequest("wrong-secret-wrong-secret", "notification", testWebhookNow, `{}`),
+			ErrInvalidEventSubSignature,
+			"",
+			"",
+			"",
+			nil,
+		},
+		{
+			"stale timestamp",
+			newSignedRequest(testWebhookSecret, "notification", testWebhookNow.Add(-11*time.Minute), `{}`),
+			ErrStaleEventSubMessage,
+			"",
+			"",
+			"",
+			nil,
+		},
+		{
+			"timestamp in the future within clock skew",
+			newSignedRequest(testWebhookSecret, "revocation", testWebhookNow.Add(30*time.Second), `{"subscription":{"status":"authorization_revoked"}}`),
+			nil,
+			EventSubMessageTypeRevocation,
+			"authorization_revoked",
+			"",
+			nil,
+		},
+		{
+			"timestamp too far in the future",
+			newSignedRequest(testWebhookSecret, "notification", testWebhookNow.Add(2*time.Minute), `{}`),
+			ErrFutureEventSubMessage,
+			"",
+			"",
+			"",
+			nil,
+		},
+		{
+			"unsupported message type",
+			newSignedRequest(testWebhookSecret, "something_else", testWebhookNow, `{}`),
+			ErrUnsupportedEventSubMessage,
+			"",
+			"",
+			"",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTestWebhook().ReadRequest(tt.r)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "e76c6bd4-55c9-4987-8304-da1588d8988b", got.Id)
+				assert.Equal(t, tt.wantType, got.Type)
+				assert.Equal(t, tt.wantStatus, got.Subscription.Status)
+				assert.Equal(t, tt.wantChallenge, got.Challenge)
+				assert.Equal(t, tt.wantEvent, got.Event)
+			}
+		})
+	}
+}
+
+func Test_EventSubWebhook_ReadRequest_conversionError(t *testing.T) {
+	body := `{"subscription":{"type":"channel.follow","version":"2","status":"enabled"},"event":{"user_id":1234}}`
+	got, err := newTestWebhook().ReadRequest(newSignedRequest(testWebhookSecret, "notification", testWebhookNow, body))
+	assert.NoError(t, err)
+	assert.Equal(t, EventSubMessageTypeNotification, got.Type)
+	assert.Nil(t, got.Event)
+	assert.ErrorContains(t, got.ConversionErr, "failed to convert channel.follow event")
+}
+
+func Test_EventSubWebhook_Handler(t *testing.T) {
+	followBody := `{"subscription":{"type":"channel.follow","version":"2","status":"enabled"},"event":{"user_id":"1234","user_name":"Cool_User"}}`
+	tests := []struct {
+		name       string
+		r          *http.Request
+		handleErr  error
+		wantStatus int
+		wantBody   string
+		wantCalled bool
+	}{
+		{
+			"verification challenge is answered",
+			newSignedRequest(testWebhookSecret, "webhook_callback_verification", testWebhookNow, `{"challenge":"pogchamp-kappa-360noscope-vohiyo"}`),
+			nil,
+			http.StatusOK,
+			"pogchamp-kappa-360noscope-vohiyo",
+			false,
+		},
+		{
+			"notification is handled",
+			newSignedRequest(testWebhookSecret, "notification", testWebhookNow, followBody),
+			nil,
+			http.StatusNoContent,
+			"",
+			true,
+		},
+		{
+			"notification that fails to be handled is retried",
+			newSignedRequest(testWebhookSecret, "notification", testWebhookNow, followBody),
+			errors.New("queue unavailable"),
+			http.StatusInternalServerError,
+			"queue unavailable\n",
+			true,
+		},
+		{
+			"notification that fails to convert is acknowledged",
+			newSignedRequest(testWebhookSecret, "notification", testWebhookNow, `{"subscription":{"type":"channel.follow","version":"2","status":"enabled"},"event":{"user_id":1234}}`),
+			nil,
+			http.StatusNoContent,
+			"",
+			true,
+		},
+		{
+			"request with invalid signature is rejected",
+			newSignedRequest("wrong-secret-wrong-secret", "notification", testWebhookNow, followBody),
+			nil,
+			http.StatusForbidden,
+			"invalid EventSub message signature\n",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := newTestWebhook().Handler(func(ctx context.Context, message *EventSubMessage) error {
+				called = true
+				return tt.handleErr
+			})
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, tt.r)
+			assert.Equal(t, tt.wantStatus, res.Code)
+			assert.Equal(t, tt.wantBody, res.Body.String())
+			assert.Equal(t, tt.wantCalled, called)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package etwitch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nicklaw5/helix/v2"
+)
+
+// DefaultEventSubWebSocketURL is the URL of Twitch's EventSub WebSocket server
+const DefaultEventSubWebSocketURL = "wss://eventsub.wss.twitch.tv/ws"
+
+// Types of message that are only sent via the EventSub WebSocket transport, as
+// described in: https://dev.twitch.tv/docs/eventsub/websocket-reference
+const (
+	EventSubMessageTypeSessionWelcome   EventSubMessageType = "session_welcome"
+	EventSubMessageTypeSessionKeepalive EventSubMessageType = "session_keepalive"
+	EventSubMessageTypeSessionReconnect EventSubMessageType = "session_reconnect"
+)
+
+// DefaultEventSubWelcomeTimeout is how long to wait for the EventSub WebSocket server
+// to send a session_welcome message after connecting
+const DefaultEventSubWelcomeTimeout = 10 * time.Second
+
+// DefaultEventSubKeepaliveSlack is how long to wait beyond the session's keepalive
+// timeout before considering the connection lost, so that a keepalive message that's
+// sent just as the timeout elapses isn't missed due to network latency
+const DefaultEventSubKeepaliveSlack = 5 * time.Second
+
+var (
+	// ErrEventSubWelcomeTimeout is returned when the EventSub WebSocket server doesn't
+	// send a session_welcome message within DefaultEventSubWelcomeTimeout of connecting
+	ErrEventSubWelcomeTimeout = errors.New("EventSub WebSocket welcome timeout elapsed")
+	// ErrEventSubKeepaliveTimeout is returned when no message has been received from
+	// the EventSub WebSocket server within the session's keepalive timeout (plus
+	// DefaultEventSubKeepaliveSlack), indicating that the connection has been lost
+	ErrEventSubKeepaliveTimeout = errors.New("EventSub WebSocket keepalive timeout elapsed")
+)
+
+// EventSubWebSocket is a client for the EventSub WebSocket transport, which allows
+// EventSub notifications to be received without exposing a public callback URL
+type EventSubWebSocket struct {
+	url            string
+	dialer         *websocket.Dialer
+	welcomeTimeout time.Duration
+	keepaliveSlack time.Duration
+}
+
+// NewEventSubWebSocket initializes a client that connects to the EventSub WebSocket
+// server at the given URL: this is DefaultEventSubWebSocketURL in production, but it
+// may be the URL of a local stand-in server (e.g. as started by 'twitch event websocket
+// start-server') for development
+func NewEventSubWebSocket(url string) *EventSubWebSocket {
+	return &EventSubWebSocket{
+		url:            url,
+		dialer:         websocket.DefaultDialer,
+		welcomeTimeout: DefaultEventSubWelcomeTimeout,
+		keepaliveSlack: DefaultEventSubKeepaliveSlack,
+	}
+}
+
+type eventSubFrame struct {
+	Metadata struct {
+		MessageId        string              `json:"message_id"`
+		MessageType      EventSubMessageType `json:"message_type"`
+		MessageTimestamp time.Time           `json:"message_timestamp"`
+	} `json:"metadata"`
+	Payload struct {
+		Session *struct {
+			Id                      string `json:"id"`
+			KeepaliveTimeoutSeconds int    `json:"keepalive_timeout_seconds"`
+			ReconnectUrl            string `json:"reconnect_url"`
+		} `json:"session"`
+		Subscription helix.EventSubSubscription `json:"subscription"`
+		Event        json.RawMessage            `json:"event"`
+	} `json:"payload"`
+}
+
+type eventSubRead struct {
+	conn *websocket.Conn
+	data []byte
+	err  error
+}
+
+// Run connects to the EventSub WebSocket server and calls handle with every message
+// that the caller needs to act on, until ctx is canceled or an error occurs:
+//
+//   - A session_welcome message is passed to handle once the session is established:
+//     its SessionId must be used to create EventSub subscriptions within a few seconds.
+//   - Each notification message is passed to handle with its Event converted via
+//     FromEventSub, exactly as with webhook notifications. If conversion fails, the
+//     message is passed to handle with its ConversionErr set, and the session remains
+//     open.
+//   - Each revocation message is passed to handle, with the reason for the revocation
+//     given by its Subscription.Status.
+//
+// Keepalive and reconnect messages are handled internally: when the server asks the
+// client to reconnect, Run connects to the new URL and migrates the session to it,
+// preserving existing subscriptions. If the connection is lost, or no message arrives
+// within the session's keepalive timeout (or, before the session is welcomed, within
+// DefaultEventSubWelcomeTimeout), Run returns an error: the caller may call Run again
+// to start a new session, whereupon its subscriptions must be recreated.
+func (c *EventSubWebSocket) Run(ctx context.Context, handle func(ctx context.Context, message *EventSubMessage) error) error {
+	conn, err := c.dial(ctx, c.url)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	reads := make(chan eventSubRead)
+	read := func(conn *websocket.Conn) {
+		for {
+			_, data, err := conn.ReadMessage()
+			select {
+			case reads <- eventSubRead{conn: conn, data: data, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	// When the server asks us to reconnect, we keep reading from the current connection
+	// until the new connection has been welcomed, then close the old one
+	var pending *websocket.Conn
+	retired := make(map[*websocket.Conn]bool)
+	defer func() {
+		close(done)
+		conn.Close()
+		if pending != nil {
+			pending.Close()
+		}
+	}()
+	go read(conn)
+
+	// Until the session is welcomed, the timer enforces the welcome timeout; thereafter,
+	// it's reset with the session's keepalive timeout whenever a message arrives
+	keepalive := time.NewTimer(c.welcomeTimeout)
+	defer keepalive.Stop()
+	var keepaliveTimeout time.Duration
+
+	for {
+		var r eventSubRead
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-keepalive.C:
+			if keepaliveTimeout == 0 {
+				return ErrEventSubWelcomeTimeout
+			}
+			return ErrEventSubKeepaliveTimeout
+		case r = <-reads:
+		}
+		if r.err != nil {
+			if retired[r.conn] {
+				continue
+			}
+			return fmt.Errorf("failed to read from EventSub WebSocket: %w", r.err)
+		}
+
+		var frame eventSubFrame
+		if err := json.Unmarshal(r.data, &frame); err != nil {
+			return fmt.Errorf("failed to unmarshal EventSub WebSocket message: %w", err)
+		}
+		if keepaliveTimeout > 0 {
+			resetTimer(keepalive, keepaliveTimeout)
+		}
+
+		message := &EventSubMessage{
+			Id:           frame.Metadata.MessageId,
+			Type:         frame.Metadata.MessageType,
+			Timestamp:    frame.Metadata.MessageTimestamp,
+			Subscription: frame.Payload.Subscription,
+		}
+		switch frame.Metadata.MessageType {
+		case EventSubMessageTypeSessionWelcome:
+			if frame.Payload.Session == nil {
+				return fmt.Errorf("EventSub WebSocket welcome message has no session")
+			}
+			keepaliveTimeout = time.Duration(frame.Payload.Session.KeepaliveTimeoutSeconds)*time.Second + c.keepaliveSlack
+			resetTimer(keepalive, keepaliveTimeout)
+			if r.conn == pending {
+				retired[conn] = true
+				conn.Close()
+				conn, pending = pending, nil
+				continue
+			}
+			message.SessionId = frame.Payload.Session.Id
+		case EventSubMessageTypeSessionKeepalive:
+			continue
+		case EventSubMessageTypeSessionReconnect:
+			if frame.Payload.Session == nil || frame.Payload.Session.ReconnectUrl == "" {
+				return fmt.Errorf("EventSub WebSocket reconnect message has no reconnect_url")
+			}
+			if pending != nil {
+				retired[pending] = true
+				pending.Close()
+			}
+			pending, err = c.dial(ctx, frame.Payload.Session.ReconnectUrl)
+			if err != nil {
+				return err
+			}
+			go read(pending)
+			continue
+		case EventSubMessageTypeNotification:
+			ev, err := FromEventSub(&frame.Payload.Subscription, frame.Payload.Event)
+			if err != nil && !errors.Is(err, ErrUnsupportedEventSubType) {
+				message.ConversionErr = fmt.Errorf("failed to convert %s event: %w", frame.Payload.Subscription.Type, err)
+			}
+			if ev != nil {
+				ev.EventSubMessageId = frame.Metadata.MessageId
+			}
+			message.Event = ev
+		case EventSubMessageTypeRevocation:
+		default:
+			return fmt.Errorf("%w: '%s'", ErrUnsupportedEventSubMessage, frame.Metadata.MessageType)
+		}
+
+		if err := handle(ctx, message); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *EventSubWebSocket) dial(ctx context.Context, url string) (*websocket.Conn, error) {
+	conn, _, err := c.dialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to EventSub WebSocket at %s: %w", url, err)
+	}
+	return conn, nil
+}
+
+// resetTimer stops t and, if d is nonzero, restarts it with a duration of d
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	if d > 0 {
+		t.Reset(d)
+	}
+}
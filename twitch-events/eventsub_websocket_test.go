@@ -0,0 +1,248 @@
+package etwitch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test EventSub WebSocket messages are adapted from:
+// https://dev.twitch.tv/docs/eventsub/websocket-reference
+
+const testWelcomeMessage = `{
+	"metadata": {
+		"message_id": "96a3f3b5-5dec-4eed-908e-e11ee657416c",
+		"message_type": "session_welcome",
+		"message_timestamp": "2023-07-19T14:56:51.634234626Z"
+	},
+	"payload": {
+		"session": {
+			"id": "AQoQILE98gtqShGmLD7AM6yJThAB",
+			"status": "connected",
+			"connected_at": "2023-07-19T14:56:51.616329898Z",
+			"keepalive_timeout_seconds": 10,
+			"reconnect_url": null
+		}
+	}
+}`
+
+const testKeepaliveMessage = `{
+	"metadata": {
+		"message_id": "84c1e79a-2a4b-4c13-ba0b-4312293e9308",
+		"message_type": "session_keepalive",
+		"message_timestamp": "2023-07-19T10:11:12.634234626Z"
+	},
+	"payload": {}
+}`
+
+const testNotificationMessage = `{
+	"metadata": {
+		"message_id": "befa7b53-d79d-478f-86b9-120f112b044e",
+		"message_type": "notification",
+		"message_timestamp": "2022-11-16T10:11:12.464757833Z",
+		"subscription_type": "channel.follow",
+		"subscription_version": "2"
+	},
+	"payload": {
+		"subscription": {
+			"id": "f1c2a387-161a-49f9-a165-0f21d7a4e1c4",
+			"status": "enabled",
+			"type": "channel.follow",
+			"version": "2",
+			"cost": 1,
+			"condition": {
+				"broadcaster_user_id": "12826"
+			},
+			"transport": {
+				"method": "websocket",
+				"session_id": "AQoQexAWVYKSTIu4ec_2VAxyuhAB"
+			},
+			"created_at": "2022-11-16T10:11:12.464757833Z"
+		},
+		"event": {
+			"user_id": "1234",
+			"user_login": "cool_user",
+			"user_name": "Cool_User",
+			"broadcaster_user_id": "1337",
+			"broadcaster_user_login": "cooler_user",
+			"broadcaster_user_name": "Cooler_User",
+			"followed_at": "2020-07-15T18:16:11.17106713Z"
+		}
+	}
+}`
+
+const testRevocationMessage = `{
+	"metadata": {
+		"message_id": "84c1e79a-2a4b-4c13-ba0b-4312293e9308",
+		"message_type": "revocation",
+		"message_timestamp": "2022-11-16T10:11:12.464757833Z",
+		"subscription_type": "channel.follow",
+		"subscription_version": "2"
+	},
+	"payload": {
+		"subscription": {
+			"id": "f1c2a387-161a-49f9-a165-0f21d7a4e1c4",
+			"status": "authorization_revoked",
+			"type": "channel.follow",
+			"version": "2"
+		}
+	}
+}`
+
+const testReconnectMessage = `{
+	"metadata": {
+		"message_id": "84c1e79a-2a4b-4c13-ba0b-4312293e9308",
+		"message_type": "session_reconnect",
+		"message_timestamp": "2022-11-18T09:10:11.634234626Z"
+	},
+	"payload": {
+		"session": {
+			"id": "AQoQexAWVYKSTIu4ec_2VAxyuhAB",
+			"status": "reconnecting",
+			"keepalive_timeout_seconds": null,
+			"reconnect_url": "RECONNECT_URL",
+			"connected_at": "2022-11-16T10:11:12.634234626Z"
+		}
+	}
+}`
+
+// newTestEventSubServer starts a stand-in EventSub WebSocket server that sends the
+// given messages to each client that connects, then holds the connection open
+func newTestEventSubServer(t *testing.T, messages ...string) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("failed to upgrade connection: %v", err)
+			return
+		}
+		defer conn.Close()
+		for _, message := range messages {
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+				return
+			}
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+var errTestDone = errors.New("done")
+
+// runUntil runs the client, collecting every message it passes to handle, until it
+// receives a message of the given type
+func runUntil(t *testing.T, c *EventSubWebSocket, last EventSubMessageType) ([]*EventSubMessage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var messages []*EventSubMessage
+	err := c.Run(ctx, func(ctx context.Context, message *EventSubMessage) error {
+		messages = append(messages, message)
+		if message.Type == last {
+			return errTestDone
+		}
+		return nil
+	})
+	return messages, err
+}
+
+func Test_EventSubWebSocket(t *testing.T) {
+	t.Run("messages are handled", func(t *testing.T) {
+		server := newTestEventSubServer(t, testWelcomeMessage, testKeepaliveMessage, testNotificationMessage, testRevocationMessage)
+		messages, err := runUntil(t, NewEventSubWebSocket(wsURL(server)), EventSubMessageTypeRevocation)
+		assert.ErrorIs(t, err, errTestDone)
+		if assert.Len(t, messages, 3) {
+			assert.Equal(t, EventSubMessageTypeSessionWelcome, messages[0].Type)
+			assert.Equal(t, "AQoQILE98gtqShGmLD7AM6yJThAB", messages[0].SessionId)
+
+			assert.Equal(t, EventSubMessageTypeNotification, messages[1].Type)
+			assert.Equal(t, "befa7b53-d79d-478f-86b9-120f112b044e", messages[1].Id)
+			assert.Equal(t, &Event{
+				Type:              EventTypeViewerFollowed,
+				Viewer:            &core.Viewer{TwitchUserId: "1234", TwitchDisplayName: "Cool_User"},
+				EventSubMessageId: "befa7b53-d79d-478f-86b9-120f112b044e",
+			}, messages[1].Event)
+
+			assert.Equal(t, EventSubMessageTypeRevocation, messages[2].Type)
+			assert.Equal(t, "authorization_revoked", messages[2].Subscription.Status)
+		}
+	})
+	t.Run("notifications that fail to convert don't end the session", func(t *testing.T) {
+		bad := strings.Replace(testNotificationMessage, `"user_id": "1234"`, `"user_id": 1234`, 1)
+		bad = strings.Replace(bad, "befa7b53-d79d-478f-86b9-120f112b044e", "5d1d8c54-0a53-4bbd-8d80-2c2a8b1ef3f7", 1)
+		server := newTestEventSubServer(t, testWelcomeMessage, bad, testNotificationMessage, testRevocationMessage)
+		messages, err := runUntil(t, NewEventSubWebSocket(wsURL(server)), EventSubMessageTypeRevocation)
+		assert.ErrorIs(t, err, errTestDone)
+		if assert.Len(t, messages, 4) {
+			assert.Equal(t, EventSubMessageTypeNotification, messages[1].Type)
+			assert.Equal(t, "5d1d8c54-0a53-4bbd-8d80-2c2a8b1ef3f7", messages[1].Id)
+			assert.Nil(t, messages[1].Event)
+			assert.ErrorContains(t, messages[1].ConversionErr, "failed to convert channel.follow event")
+
+			assert.Equal(t, EventSubMessageTypeNotification, messages[2].Type)
+			assert.NoError(t, messages[2].ConversionErr)
+			assert.Equal(t, EventTypeViewerFollowed, messages[2].Event.Type)
+		}
+	})
+	t.Run("reconnect URL is followed", func(t *testing.T) {
+		newServer := newTestEventSubServer(t, strings.Replace(testWelcomeMessage, "AQoQILE98gtqShGmLD7AM6yJThAB", "new-session", 1), testNotificationMessage)
+		reconnect := strings.Replace(testReconnectMessage, "RECONNECT_URL", wsURL(newServer), 1)
+		oldServer := newTestEventSubServer(t, testWelcomeMessage, reconnect)
+
+		messages, err := runUntil(t, NewEventSubWebSocket(wsURL(oldServer)), EventSubMessageTypeNotification)
+		assert.ErrorIs(t, err, errTestDone)
+		if assert.Len(t, messages, 2) {
+			assert.Equal(t, EventSubMessageTypeSessionWelcome, messages[0].Type)
+			assert.Equal(t, "AQoQILE98gtqShGmLD7AM6yJThAB", messages[0].SessionId)
+			assert.Equal(t, EventSubMessageTypeNotification, messages[1].Type)
+		}
+	})
+	t.Run("keepalive timeout is enforced", func(t *testing.T) {
+		welcome := strings.Replace(testWelcomeMessage, `"keepalive_timeout_seconds": 10`, `"keepalive_timeout_seconds": 1`, 1)
+		server := newTestEventSubServer(t, welcome)
+		c := NewEventSubWebSocket(wsURL(server))
+		c.keepaliveSlack = 500 * time.Millisecond
+		start := time.Now()
+		messages, err := runUntil(t, c, EventSubMessageTypeRevocation)
+		assert.ErrorIs(t, err, ErrEventSubKeepaliveTimeout)
+		assert.GreaterOrEqual(t, time.Since(start), 1500*time.Millisecond)
+		assert.Len(t, messages, 1)
+	})
+	t.Run("welcome timeout is enforced", func(t *testing.T) {
+		server := newTestEventSubServer(t)
+		c := NewEventSubWebSocket(wsURL(server))
+		c.welcomeTimeout = 100 * time.Millisecond
+		messages, err := runUntil(t, c, EventSubMessageTypeRevocation)
+		assert.ErrorIs(t, err, ErrEventSubWelcomeTimeout)
+		assert.Empty(t, messages)
+	})
+	t.Run("welcome timeout isn't reset by other messages", func(t *testing.T) {
+		server := newTestEventSubServer(t, testKeepaliveMessage)
+		c := NewEventSubWebSocket(wsURL(server))
+		c.welcomeTimeout = 100 * time.Millisecond
+		_, err := runUntil(t, c, EventSubMessageTypeRevocation)
+		assert.ErrorIs(t, err, ErrEventSubWelcomeTimeout)
+	})
+	t.Run("unsupported message types are rejected", func(t *testing.T) {
+		unknown := strings.Replace(testKeepaliveMessage, "session_keepalive", "session_dance", 1)
+		server := newTestEventSubServer(t, testWelcomeMessage, unknown)
+		_, err := runUntil(t, NewEventSubWebSocket(wsURL(server)), EventSubMessageTypeRevocation)
+		assert.ErrorIs(t, err, ErrUnsupportedEventSubMessage)
+	})
+}
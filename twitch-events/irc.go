@@ -0,0 +1,420 @@
+package etwitch
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golden-vcr/schemas/core"
+)
+
+var (
+	ErrInvalidIRCMessage     = errors.New("invalid IRC message")
+	ErrUnsupportedIRCMessage = errors.New("unsupported IRC message")
+)
+
+// Logins that Twitch chat attributes cheers and gift subs to when the viewer has chosen
+// to remain anonymous
+const (
+	ircAnonymousCheererLogin = "ananonymouscheerer"
+	ircAnonymousGifterLogin  = "ananonymousgifter"
+)
+
+// IRCMessage is a single message received from Twitch chat, parsed from a raw IRCv3
+// line as described in: https://dev.twitch.tv/docs/irc
+type IRCMessage struct {
+	// Tags holds the message's IRCv3 tags, with their values unescaped
+	Tags map[string]string
+	// Source is the prefix identifying the sender, e.g. "ronni!ronni@ronni.tmi.twitch.tv"
+	// or "tmi.twitch.tv", or an empty string if the message has no prefix
+	Source string
+	// Command is the IRC command or numeric reply, e.g. "PRIVMSG" or "USERNOTICE"
+	Command string
+	// Params holds the command's parameters, including the trailing parameter (e.g. the
+	// text of a chat message) if present
+	Params []string
+}
+
+// ParseIRC parses a single raw IRC line, with or without a trailing CRLF
+func ParseIRC(line string) (*IRCMessage, error) {
+	line = strings.TrimRight(line, "\r\n")
+	m := &IRCMessage{Tags: make(map[string]string)}
+
+	if rest, ok := strings.CutPrefix(line, "@"); ok {
+		tags, after, ok := strings.Cut(rest, " ")
+		if !ok {
+			return nil, fmt.Errorf("%w: no command after tags", ErrInvalidIRCMessage)
+		}
+		for _, tag := range strings.Split(tags, ";") {
+			key, value, _ := strings.Cut(tag, "=")
+			m.Tags[key] = unescapeIRCTagValue(value)
+		}
+		line = strings.TrimLeft(after, " ")
+	}
+	if rest, ok := strings.CutPrefix(line, ":"); ok {
+		source, after, ok := strings.Cut(rest, " ")
+		if !ok {
+			return nil, fmt.Errorf("%w: no command after source", ErrInvalidIRCMessage)
+		}
+		m.Source = source
+		line = strings.TrimLeft(after, " ")
+	}
+
+	command, params, _ := strings.Cut(line, " ")
+	if command == "" {
+		return nil, fmt.Errorf("%w: no command", ErrInvalidIRCMessage)
+	}
+	m.Command = command
+	for params != "" {
+		if trailing, ok := strings.CutPrefix(params, ":"); ok {
+			m.Params = append(m.Params, trailing)
+			break
+		}
+		var param string
+		param, params, _ = strings.Cut(params, " ")
+		if param != "" {
+			m.Params = append(m.Params, param)
+		}
+	}
+	return m, nil
+}
+
+// unescapeIRCTagValue reverses the escaping that IRCv3 applies to tag values
+func unescapeIRCTagValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		if i == len(value) {
+			break
+		}
+		switch value[i] {
+		case ':':
+			b.WriteByte(';')
+		case 's':
+			b.WriteByte(' ')
+		case 'r':
+			b.WriteByte('\r')
+		case 'n':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
+// Login returns the login name of the user who sent the message, as given by its login
+// tag or, failing that, by its source
+func (m *IRCMessage) Login() string {
+	if login := m.Tags["login"]; login != "" {
+		return login
+	}
+	nick, _, _ := strings.Cut(m.Source, "!")
+	if strings.Contains(nick, ".") {
+		// Messages sent by the server itself (e.g. from tmi.twitch.tv) have no user
+		return ""
+	}
+	return nick
+}
+
+// Badges returns the chat badges displayed alongside the sender's name, mapping each
+// badge name (e.g. "subscriber", "moderator") to its version
+func (m *IRCMessage) Badges() map[string]string {
+	badges := make(map[string]string)
+	if m.Tags["badges"] == "" {
+		return badges
+	}
+	for _, badge := range strings.Split(m.Tags["badges"], ",") {
+		name, version, _ := strings.Cut(badge, "/")
+		badges[name] = version
+	}
+	return badges
+}
+
+// Text returns the message's trailing parameter, e.g. the text of a chat message
+func (m *IRCMessage) Text() string {
+	if len(m.Params) < 2 {
+		return ""
+	}
+	return m.Params[len(m.Params)-1]
+}
+
+// viewer returns the Viewer who sent the message
+func (m *IRCMessage) viewer() *core.Viewer {
+	displayName := m.Tags["display-name"]
+	if displayName == "" {
+		displayName = m.Login()
+	}
+	return &core.Viewer{
+		TwitchUserId:      m.Tags["user-id"],
+		TwitchDisplayName: displayName,
+	}
+}
+
+// emotes returns the positions of the emotes in the message's text, as given by its
+// emotes tag, e.g. "25:0-4,12-16/1902:6-10"
+func (m *IRCMessage) emotes() []emoteSpan {
+	var spans []emoteSpan
+	if m.Tags["emotes"] == "" {
+		return spans
+	}
+	for _, emote := range strings.Split(m.Tags["emotes"], "/") {
+		id, ranges, _ := strings.Cut(emote, ":")
+		for _, r := range strings.Split(ranges, ",") {
+			beginStr, endStr, _ := strings.Cut(r, "-")
+			begin, err := strconv.Atoi(beginStr)
+			if err != nil {
+				continue
+			}
+			end, err := strconv.Atoi(endStr)
+			if err != nil {
+				continue
+			}
+			spans = append(spans, emoteSpan{begin: begin, end: end, id: id})
+		}
+	}
+	return spans
+}
+
+func (m *IRCMessage) intTag(key string) (int, error) {
+	n, err := strconv.Atoi(m.Tags[key])
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s tag: %w", key, err)
+	}
+	return n, nil
+}
+
+func (m *IRCMessage) sentAt() (time.Time, error) {
+	ms, err := strconv.ParseInt(m.Tags["tmi-sent-ts"], 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid tmi-sent-ts tag: %w", err)
+	}
+	return time.UnixMilli(ms).UTC(), nil
+}
+
+// FromIRC converts a raw line received from Twitch chat to the Events that it
+// describes. Cheers (PRIVMSG lines with a bits tag), subs, resubs, gift subs, and raids
+// (USERNOTICE lines), and bans and timeouts (CLEARCHAT lines) are supported: for any
+// other message, ErrUnsupportedIRCMessage is returned. Most lines result in a single
+// Event, but a single gift sub results in two: one for the viewer who gifted it, and
+// one for the viewer who received it. Since CLEARCHAT lines don't include the banned
+// viewer's display name, the TwitchDisplayName of a ban event converted from Twitch
+// chat holds the viewer's login name instead.
+func FromIRC(line string, opts ...ConvertOption) ([]*Event, error) {
+	m, err := ParseIRC(line)
+	if err != nil {
+		return nil, err
+	}
+	o := newConvertOptions(opts)
+	switch m.Command {
+	case "PRIVMSG":
+		if m.Tags["bits"] != "" {
+			return ircEvents(fromIRCCheer(m))
+		}
+	case "USERNOTICE":
+		switch m.Tags["msg-id"] {
+		case "sub":
+			return ircEvents(fromIRCSub(m, o.creditMultipliers))
+		case "resub":
+			return ircEvents(fromIRCResub(m, o.creditMultipliers))
+		case "subgift":
+			return fromIRCSubGift(m, o.creditMultipliers)
+		case "submysterygift":
+			return ircEvents(fromIRCSubMysteryGift(m, o.creditMultipliers))
+		case "raid":
+			return ircEvents(fromIRCRaid(m))
+		}
+	case "CLEARCHAT":
+		if m.Tags["target-user-id"] != "" {
+			return ircEvents(fromIRCClearChat(m))
+		}
+	}
+	return nil, ErrUnsupportedIRCMessage
+}
+
+// ircEvents returns the result of converting an IRC line that describes a single Event
+func ircEvents(ev *Event, err error) ([]*Event, error) {
+	if err != nil {
+		return nil, err
+	}
+	return []*Event{ev}, nil
+}
+
+func fromIRCCheer(m *IRCMessage) (*Event, error) {
+	numBits, err := m.intTag("bits")
+	if err != nil {
+		return nil, err
+	}
+	var viewer *core.Viewer
+	if m.Login() != ircAnonymousCheererLogin {
+		viewer = m.viewer()
+	}
+	return &Event{
+		Type:   EventTypeViewerCheered,
+		Viewer: viewer,
+		Payload: &Payload{
+			ViewerCheered: &PayloadViewerCheered{
+				NumBits:          numBits,
+				Message:          m.Text(),
+				MessageFragments: parseMessage(m.Text(), m.emotes(), true),
+			},
+		},
+	}, nil
+}
+
+func fromIRCSub(m *IRCMessage, creditMultipliers CreditMultiplierPolicy) (*Event, error) {
+	tier := parseSubTier(m.Tags["msg-param-sub-plan"])
+	return &Event{
+		Type:   EventTypeViewerSubscribed,
+		Viewer: m.viewer(),
+		Payload: &Payload{
+			ViewerSubscribed: &PayloadViewerSubscribed{
+				Tier:             tier,
+				CreditMultiplier: creditMultipliers.CreditMultiplier(tier),
+			},
+		},
+	}, nil
+}
+
+func fromIRCResub(m *IRCMessage, creditMultipliers CreditMultiplierPolicy) (*Event, error) {
+	tier := parseSubTier(m.Tags["msg-param-sub-plan"])
+	numCumulativeMonths, err := m.intTag("msg-param-cumulative-months")
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		Type:   EventTypeViewerResubscribed,
+		Viewer: m.viewer(),
+		Payload: &Payload{
+			ViewerResubscribed: &PayloadViewerResubscribed{
+				Tier:                tier,
+				CreditMultiplier:    creditMultipliers.CreditMultiplier(tier),
+				NumCumulativeMonths: numCumulativeMonths,
+				Message:             m.Text(),
+				MessageFragments:    parseMessage(m.Text(), m.emotes(), false),
+			},
+		},
+	}, nil
+}
+
+// fromIRCSubGift converts a subgift line, which identifies a single viewer who received
+// a gift sub. When a viewer gifts several subs to the community at once, Twitch sends a
+// submysterygift line describing the entire gift, followed by a subgift line (tagged
+// with the ID of that community gift) for each recipient. Otherwise, the subgift line
+// is the only record of the gift, so it results in a ViewerGiftedSubs event for the
+// gifter as well.
+func fromIRCSubGift(m *IRCMessage, creditMultipliers CreditMultiplierPolicy) ([]*Event, error) {
+	tier := parseSubTier(m.Tags["msg-param-sub-plan"])
+	received := &Event{
+		Type: EventTypeViewerReceivedGiftSub,
+		Viewer: &core.Viewer{
+			TwitchUserId:      m.Tags["msg-param-recipient-id"],
+			TwitchDisplayName: m.Tags["msg-param-recipient-display-name"],
+		},
+		Payload: &Payload{
+			ViewerReceivedGiftSub: &PayloadViewerReceivedGiftSub{
+				Tier:             tier,
+				CreditMultiplier: creditMultipliers.CreditMultiplier(tier),
+			},
+		},
+	}
+	if m.Tags["msg-param-community-gift-id"] != "" {
+		return []*Event{received}, nil
+	}
+
+	var viewer *core.Viewer
+	if m.Login() != ircAnonymousGifterLogin {
+		viewer = m.viewer()
+	}
+	gifted := &Event{
+		Type:   EventTypeViewerGiftedSubs,
+		Viewer: viewer,
+		Payload: &Payload{
+			ViewerGiftedSubs: &PayloadViewerGiftedSubs{
+				Tier:             tier,
+				CreditMultiplier: creditMultipliers.CreditMultiplier(tier),
+				NumSubscriptions: 1,
+			},
+		},
+	}
+	return []*Event{gifted, received}, nil
+}
+
+func fromIRCSubMysteryGift(m *IRCMessage, creditMultipliers CreditMultiplierPolicy) (*Event, error) {
+	tier := parseSubTier(m.Tags["msg-param-sub-plan"])
+	numSubscriptions, err := m.intTag("msg-param-mass-gift-count")
+	if err != nil {
+		return nil, err
+	}
+	var viewer *core.Viewer
+	if m.Login() != ircAnonymousGifterLogin {
+		viewer = m.viewer()
+	}
+	return &Event{
+		Type:   EventTypeViewerGiftedSubs,
+		Viewer: viewer,
+		Payload: &Payload{
+			ViewerGiftedSubs: &PayloadViewerGiftedSubs{
+				Tier:             tier,
+				CreditMultiplier: creditMultipliers.CreditMultiplier(tier),
+				NumSubscriptions: numSubscriptions,
+			},
+		},
+	}, nil
+}
+
+func fromIRCRaid(m *IRCMessage) (*Event, error) {
+	numRaiders, err := m.intTag("msg-param-viewerCount")
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		Type:   EventTypeViewerRaided,
+		Viewer: m.viewer(),
+		Payload: &Payload{
+			ViewerRaided: &PayloadViewerRaided{
+				NumRaiders: numRaiders,
+			},
+		},
+	}, nil
+}
+
+func fromIRCClearChat(m *IRCMessage) (*Event, error) {
+	var expiresAt *time.Time
+	if m.Tags["ban-duration"] != "" {
+		banDuration, err := m.intTag("ban-duration")
+		if err != nil {
+			return nil, err
+		}
+		sentAt, err := m.sentAt()
+		if err != nil {
+			return nil, err
+		}
+		t := sentAt.Add(time.Duration(banDuration) * time.Second)
+		expiresAt = &t
+	}
+	// CLEARCHAT identifies the banned user only by ID and login name, so the login must
+	// stand in for their display name: the two usually differ only in capitalization,
+	// but they may differ entirely for users with localized display names
+	return &Event{
+		Type: EventTypeViewerBanned,
+		Viewer: &core.Viewer{
+			TwitchUserId:      m.Tags["target-user-id"],
+			TwitchDisplayName: m.Text(),
+		},
+		Payload: &Payload{
+			ViewerBanned: &PayloadViewerBanned{
+				ExpiresAt: expiresAt,
+			},
+		},
+	}, nil
+}
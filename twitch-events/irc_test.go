@@ -0,0 +1,174 @@
+package etwitch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseIRC(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		wantErr error
+		want    *IRCMessage
+	}{
+		{
+			"message with tags, source, and trailing parameter",
+			"@badges=broadcaster/1,subscriber/12;display-name=Golden\\sVCR;empty= :goldenvcr!goldenvcr@goldenvcr.tmi.twitch.tv PRIVMSG #goldenvcr :hello :) world\r\n",
+			nil,
+			&IRCMessage{
+				Tags: map[string]string{
+					"badges":       "broadcaster/1,subscriber/12",
+					"display-name": "Golden VCR",
+					"empty":        "",
+				},
+				Source:  "goldenvcr!goldenvcr@goldenvcr.tmi.twitch.tv",
+				Command: "PRIVMSG",
+				Params:  []string{"#goldenvcr", "hello :) world"},
+			},
+		},
+		{
+			"message with no tags or source",
+			"PING :tmi.twitch.tv",
+			nil,
+			&IRCMessage{
+				Tags:    map[string]string{},
+				Command: "PING",
+				Params:  []string{"tmi.twitch.tv"},
+			},
+		},
+		{
+			"escaped tag values",
+			`@system-msg=a\:b\\c\sd\ne\r;trailing=x\ :tmi.twitch.tv USERNOTICE #goldenvcr`,
+			nil,
+			&IRCMessage{
+				Tags: map[string]string{
+					"system-msg": "a;b\\c d\ne\r",
+					"trailing":   "x",
+				},
+				Source:  "tmi.twitch.tv",
+				Command: "USERNOTICE",
+				Params:  []string{"#goldenvcr"},
+			},
+		},
+		{
+			"tags with no command",
+			"@badges=",
+			ErrInvalidIRCMessage,
+			nil,
+		},
+		{
+			"source with no command",
+			":tmi.twitch.tv",
+			ErrInvalidIRCMessage,
+			nil,
+		},
+		{
+			"empty line",
+			"",
+			ErrInvalidIRCMessage,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIRC(tt.line)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_IRCMessage_Badges(t *testing.T) {
+	m, err := ParseIRC("@badge-info=subscriber/14;badges=moderator/1,subscriber/12,bits/1000 :wasabimilkshake!wasabimilkshake@wasabimilkshake.tmi.twitch.tv PRIVMSG #goldenvcr :hi")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"moderator": "1", "subscriber": "12", "bits": "1000"}, m.Badges())
+	assert.Equal(t, "wasabimilkshake", m.Login())
+
+	m, err = ParseIRC("@badges= :tmi.twitch.tv USERNOTICE #goldenvcr")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{}, m.Badges())
+	assert.Equal(t, "", m.Login())
+}
+
+func Test_FromIRC(t *testing.T) {
+	// Each .irc file in testdata/irc holds a line received from Twitch chat, alongside a
+	// .json file holding the events that it should be converted to
+	paths, err := filepath.Glob(filepath.Join("testdata", "irc", "*.irc"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, paths)
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".irc")
+		t.Run(name, func(t *testing.T) {
+			line, err := os.ReadFile(path)
+			assert.NoError(t, err)
+			want, err := os.ReadFile(strings.TrimSuffix(path, ".irc") + ".json")
+			assert.NoError(t, err)
+
+			events, err := FromIRC(string(line))
+			assert.NoError(t, err)
+			for _, ev := range events {
+				assert.NoError(t, ev.Validate())
+			}
+			got, err := json.MarshalIndent(events, "", "  ")
+			assert.NoError(t, err)
+			assert.Equal(t, string(want), string(got)+"\n")
+		})
+	}
+}
+
+func Test_FromIRC_unsupported(t *testing.T) {
+	// Lines in testdata/irc/unsupported are valid, but don't result in events
+	paths, err := filepath.Glob(filepath.Join("testdata", "irc", "unsupported", "*.irc"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, paths)
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".irc")
+		t.Run(name, func(t *testing.T) {
+			line, err := os.ReadFile(path)
+			assert.NoError(t, err)
+
+			events, err := FromIRC(string(line))
+			assert.ErrorIs(t, err, ErrUnsupportedIRCMessage)
+			assert.Nil(t, events)
+		})
+	}
+}
+
+func Test_FromIRC_errors(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{
+			"cheer with non-numeric bits",
+			"@bits=lots;display-name=ronni;user-id=12345678 :ronni!ronni@ronni.tmi.twitch.tv PRIVMSG #ronni :cheer100",
+		},
+		{
+			"resub with no cumulative months",
+			"@display-name=ronni;login=ronni;msg-id=resub;msg-param-sub-plan=1000;user-id=12345678 :tmi.twitch.tv USERNOTICE #ronni",
+		},
+		{
+			"timeout with no timestamp",
+			"@ban-duration=350;target-user-id=87654321 :tmi.twitch.tv CLEARCHAT #dallas :ronni",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := FromIRC(tt.line)
+			assert.Error(t, err)
+			assert.NotErrorIs(t, err, ErrUnsupportedIRCMessage)
+			assert.Nil(t, events)
+		})
+	}
+}
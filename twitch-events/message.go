@@ -0,0 +1,228 @@
+package etwitch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MessageFragments is a message sent by a viewer (e.g. with a cheer or a resub), split
+// into fragments of plain text, emotes, and cheermotes
+type MessageFragments []MessageFragment
+
+// MessageFragment is a contiguous section of a viewer's message
+type MessageFragment struct {
+	Type MessageFragmentType `json:"type"`
+	// Text is the section of the message that this fragment covers, verbatim: for an
+	// emote or cheermote, it's the code that the viewer typed, e.g. "Kappa" or "Cheer100"
+	Text string `json:"text"`
+	// Emote identifies the emote, for emote fragments only
+	Emote *MessageEmote `json:"emote"`
+	// Cheermote describes the cheermote, for cheermote fragments only
+	Cheermote *MessageCheermote `json:"cheermote"`
+}
+
+// MessageFragmentType indicates what a MessageFragment represents
+type MessageFragmentType string
+
+const (
+	MessageFragmentTypeText      MessageFragmentType = "text"
+	MessageFragmentTypeEmote     MessageFragmentType = "emote"
+	MessageFragmentTypeCheermote MessageFragmentType = "cheermote"
+)
+
+func (MessageFragmentType) EnumValues() []string {
+	return []string{
+		string(MessageFragmentTypeText),
+		string(MessageFragmentTypeEmote),
+		string(MessageFragmentTypeCheermote),
+	}
+}
+
+// MessageEmote identifies a Twitch emote
+type MessageEmote struct {
+	Id string `json:"id"`
+}
+
+// MessageCheermote describes a cheermote, which a viewer types in order to cheer a
+// number of bits, e.g. "Cheer100"
+type MessageCheermote struct {
+	Prefix  string `json:"prefix"`
+	NumBits int    `json:"num_bits"`
+}
+
+// CleanText returns only the plain text of the message, with all emotes and cheermotes
+// removed, and with whitespace collapsed, e.g. "Cheer100 ghost of a cat Kappa" becomes
+// "ghost of a cat"
+func (m MessageFragments) CleanText() string {
+	var b strings.Builder
+	for _, fragment := range m {
+		if fragment.Type == MessageFragmentTypeText {
+			b.WriteString(fragment.Text)
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// CleanText returns the plain text of the viewer's cheer message, as described by
+// MessageFragments.CleanText. Events from producers that predate MessageFragments carry
+// only the verbatim Message: in that case, cheermotes are parsed from the Message and
+// removed, but emotes can't be identified, so they're left in place.
+func (p PayloadViewerCheered) CleanText() string {
+	if p.MessageFragments == nil {
+		return parseMessage(p.Message, nil, true).CleanText()
+	}
+	return p.MessageFragments.CleanText()
+}
+
+// CleanText returns the plain text of the viewer's resub message, falling back to the
+// verbatim Message for events from producers that predate MessageFragments. Resub
+// messages can't contain cheermotes, so only whitespace is normalized in that case.
+func (p PayloadViewerResubscribed) CleanText() string {
+	if p.MessageFragments == nil {
+		return parseMessage(p.Message, nil, false).CleanText()
+	}
+	return p.MessageFragments.CleanText()
+}
+
+// cheermotePrefixes lists the prefixes of Twitch's global cheermotes, in lowercase:
+// channels' custom cheermotes are not recognized
+var cheermotePrefixes = map[string]bool{
+	"cheer":         true,
+	"doodlecheer":   true,
+	"biblethump":    true,
+	"cheerwhal":     true,
+	"corgo":         true,
+	"uni":           true,
+	"showlove":      true,
+	"party":         true,
+	"seemsgood":     true,
+	"pride":         true,
+	"kappa":         true,
+	"frankerz":      true,
+	"heyguys":       true,
+	"dansgame":      true,
+	"elegiggle":     true,
+	"trihard":       true,
+	"kreygasm":      true,
+	"4head":         true,
+	"swiftrage":     true,
+	"notlikethis":   true,
+	"failfish":      true,
+	"vohiyo":        true,
+	"pjsalt":        true,
+	"mrdestructoid": true,
+	"bday":          true,
+	"ripcheer":      true,
+	"shamrock":      true,
+	"streamlabs":    true,
+	"muxy":          true,
+	"holidaycheer":  true,
+	"goal":          true,
+	"anon":          true,
+	"charity":       true,
+}
+
+// parseCheermote returns the cheermote described by word, if it's a recognized prefix
+// followed by a positive number of bits
+func parseCheermote(word string) (*MessageCheermote, bool) {
+	i := strings.LastIndexFunc(word, func(r rune) bool { return r < '0' || r > '9' }) + 1
+	if i == 0 || i == len(word) {
+		return nil, false
+	}
+	prefix := word[:i]
+	if !cheermotePrefixes[strings.ToLower(prefix)] {
+		return nil, false
+	}
+	numBits, err := strconv.Atoi(word[i:])
+	if err != nil || numBits <= 0 {
+		return nil, false
+	}
+	return &MessageCheermote{Prefix: prefix, NumBits: numBits}, true
+}
+
+// emoteSpan records the position of an emote within a message, as the inclusive range
+// of indexes of the unicode code points that it covers, matching the convention used by
+// both EventSub and Twitch chat
+type emoteSpan struct {
+	begin int
+	end   int
+	id    string
+}
+
+// parseMessage splits text into fragments: emotes are identified by the given spans,
+// which may be given in any order; if parseCheermotes is true, any word in the
+// remaining text that matches a global cheermote becomes a cheermote fragment
+func parseMessage(text string, emotes []emoteSpan, parseCheermotes bool) MessageFragments {
+	runes := []rune(text)
+	sorted := append([]emoteSpan(nil), emotes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].begin < sorted[j].begin })
+
+	fragments := MessageFragments{}
+	pos := 0
+	for _, emote := range sorted {
+		// Ignore any emote whose position is inconsistent with the text
+		if emote.begin < pos || emote.end < emote.begin || emote.end >= len(runes) {
+			continue
+		}
+		appendText(&fragments, string(runes[pos:emote.begin]), parseCheermotes)
+		fragments = append(fragments, MessageFragment{
+			Type:  MessageFragmentTypeEmote,
+			Text:  string(runes[emote.begin : emote.end+1]),
+			Emote: &MessageEmote{Id: emote.id},
+		})
+		pos = emote.end + 1
+	}
+	appendText(&fragments, string(runes[pos:]), parseCheermotes)
+	return fragments
+}
+
+// appendText appends fragments for s, which contains no emotes: if parseCheermotes is
+// true, each word that matches a global cheermote becomes a cheermote fragment
+func appendText(fragments *MessageFragments, s string, parseCheermotes bool) {
+	if !parseCheermotes {
+		appendTextFragment(fragments, s)
+		return
+	}
+	for s != "" {
+		// Split off the next word, along with any whitespace that precedes it
+		start := strings.IndexFunc(s, func(r rune) bool { return !unicode.IsSpace(r) })
+		if start < 0 {
+			appendTextFragment(fragments, s)
+			return
+		}
+		end := strings.IndexFunc(s[start:], unicode.IsSpace)
+		if end < 0 {
+			end = len(s)
+		} else {
+			end += start
+		}
+		if cheermote, ok := parseCheermote(s[start:end]); ok {
+			appendTextFragment(fragments, s[:start])
+			*fragments = append(*fragments, MessageFragment{
+				Type:      MessageFragmentTypeCheermote,
+				Text:      s[start:end],
+				Cheermote: cheermote,
+			})
+		} else {
+			appendTextFragment(fragments, s[:end])
+		}
+		s = s[end:]
+	}
+}
+
+// appendTextFragment appends s as a text fragment, merging it with the preceding
+// fragment if that's also text
+func appendTextFragment(fragments *MessageFragments, s string) {
+	if s == "" {
+		return
+	}
+	if n := len(*fragments); n > 0 && (*fragments)[n-1].Type == MessageFragmentTypeText {
+		(*fragments)[n-1].Text += s
+		return
+	}
+	*fragments = append(*fragments, MessageFragment{Type: MessageFragmentTypeText, Text: s})
+}
@@ -0,0 +1,162 @@
+package etwitch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseMessage(t *testing.T) {
+	tests := []struct {
+		name            string
+		text            string
+		emotes          []emoteSpan
+		parseCheermotes bool
+		want            MessageFragments
+	}{
+		{
+			"empty message",
+			"",
+			nil,
+			true,
+			MessageFragments{},
+		},
+		{
+			"plain text",
+			"ghost of a seal",
+			nil,
+			true,
+			MessageFragments{
+				{Type: MessageFragmentTypeText, Text: "ghost of a seal"},
+			},
+		},
+		{
+			"cheermotes with various prefixes",
+			"Cheer100 ghost of a cat  doodlecheer50 mp3 Kappa 4Head1",
+			nil,
+			true,
+			MessageFragments{
+				{Type: MessageFragmentTypeCheermote, Text: "Cheer100", Cheermote: &MessageCheermote{Prefix: "Cheer", NumBits: 100}},
+				{Type: MessageFragmentTypeText, Text: " ghost of a cat  "},
+				{Type: MessageFragmentTypeCheermote, Text: "doodlecheer50", Cheermote: &MessageCheermote{Prefix: "doodlecheer", NumBits: 50}},
+				{Type: MessageFragmentTypeText, Text: " mp3 Kappa "},
+				{Type: MessageFragmentTypeCheermote, Text: "4Head1", Cheermote: &MessageCheermote{Prefix: "4Head", NumBits: 1}},
+			},
+		},
+		{
+			"cheermotes are not parsed unless requested",
+			"Cheer100 ghost",
+			nil,
+			false,
+			MessageFragments{
+				{Type: MessageFragmentTypeText, Text: "Cheer100 ghost"},
+			},
+		},
+		{
+			"cheermote-like words with zero bits are text",
+			"Cheer0 ghost",
+			nil,
+			true,
+			MessageFragments{
+				{Type: MessageFragmentTypeText, Text: "Cheer0 ghost"},
+			},
+		},
+		{
+			"emotes out of order, after multi-byte characters",
+			"👻 Kappa boo PogChamp",
+			[]emoteSpan{{begin: 12, end: 19, id: "88"}, {begin: 2, end: 6, id: "25"}},
+			true,
+			MessageFragments{
+				{Type: MessageFragmentTypeText, Text: "👻 "},
+				{Type: MessageFragmentTypeEmote, Text: "Kappa", Emote: &MessageEmote{Id: "25"}},
+				{Type: MessageFragmentTypeText, Text: " boo "},
+				{Type: MessageFragmentTypeEmote, Text: "PogChamp", Emote: &MessageEmote{Id: "88"}},
+			},
+		},
+		{
+			"emotes with inconsistent positions are ignored",
+			"Kappa",
+			[]emoteSpan{{begin: 3, end: 9, id: "25"}, {begin: 4, end: 2, id: "25"}},
+			true,
+			MessageFragments{
+				{Type: MessageFragmentTypeText, Text: "Kappa"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseMessage(tt.text, tt.emotes, tt.parseCheermotes)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_MessageFragments_CleanText(t *testing.T) {
+	tests := []struct {
+		name string
+		m    MessageFragments
+		want string
+	}{
+		{
+			"no fragments",
+			nil,
+			"",
+		},
+		{
+			"cheermotes and emotes are removed",
+			parseMessage("Cheer100 ghost of a cat Kappa", []emoteSpan{{begin: 24, end: 28, id: "25"}}, true),
+			"ghost of a cat",
+		},
+		{
+			"emotes adjacent to text are separated by whitespace",
+			parseMessage("seal\tKappaKappa  seal ", []emoteSpan{{begin: 5, end: 9, id: "25"}, {begin: 10, end: 14, id: "25"}}, false),
+			"seal seal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.m.CleanText())
+		})
+	}
+}
+
+func Test_Payload_CleanText(t *testing.T) {
+	tests := []struct {
+		name string
+		p    interface{ CleanText() string }
+		want string
+	}{
+		{
+			"cheer with fragments",
+			PayloadViewerCheered{
+				NumBits:          100,
+				Message:          "Cheer100 ghost of a cat Kappa",
+				MessageFragments: parseMessage("Cheer100 ghost of a cat Kappa", []emoteSpan{{begin: 24, end: 28, id: "25"}}, true),
+			},
+			"ghost of a cat",
+		},
+		{
+			"cheer that predates fragments",
+			PayloadViewerCheered{NumBits: 100, Message: "Cheer100 ghost of a cat"},
+			"ghost of a cat",
+		},
+		{
+			"resub with fragments",
+			PayloadViewerResubscribed{
+				Message:          "seal Kappa seal",
+				MessageFragments: parseMessage("seal Kappa seal", []emoteSpan{{begin: 5, end: 9, id: "25"}}, false),
+			},
+			"seal seal",
+		},
+		{
+			"resub that predates fragments",
+			PayloadViewerResubscribed{Message: "  happy   anniversary cheer5 "},
+			"happy anniversary cheer5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.p.CleanText())
+		})
+	}
+}
@@ -2,20 +2,115 @@ package etwitch
 
 import "fmt"
 
-func getCreditMultiplierFromTier(tier string) (int, error) {
-	// We expect the "tier" value in EventSub messages to be one of the following:
+// SubTier identifies the tier of a Twitch subscription. Producers that predate SubTier
+// omit it from subscription-related payloads, so an empty SubTier is accepted in those
+// payloads: consumers should rely on their CreditMultiplier in that case.
+type SubTier string
+
+const (
+	SubTier1     SubTier = "1000"
+	SubTier2     SubTier = "2000"
+	SubTier3     SubTier = "3000"
+	SubTierPrime SubTier = "prime"
+	// SubTierUnknown indicates that Twitch reported a tier that we don't recognize
+	SubTierUnknown SubTier = "unknown"
+)
+
+func (SubTier) EnumValues() []string {
+	return []string{
+		string(SubTier1),
+		string(SubTier2),
+		string(SubTier3),
+		string(SubTierPrime),
+		string(SubTierUnknown),
+	}
+}
+
+// Validate verifies that a SubTier is one of the SubTier constants
+func (t SubTier) Validate() error {
+	switch t {
+	case SubTier1, SubTier2, SubTier3, SubTierPrime, SubTierUnknown:
+		return nil
+	}
+	return fmt.Errorf("'%s' is not a valid sub tier", t)
+}
+
+// parseSubTier interprets a tier value as reported by Twitch: EventSub messages report
+// "1000", "2000", or "3000" (with Prime subs reported as "1000"), while Twitch chat
+// reports Prime subs as "Prime"
+func parseSubTier(tier string) SubTier {
 	switch tier {
 	case "1000":
-		// Tier 1 subs are the baseline at $5; fun points are credited 1x
-		return 1, nil
+		return SubTier1
 	case "2000":
-		// Tier 2 subs cost $10; fun point credits are doubled
-		return 2, nil
+		return SubTier2
 	case "3000":
-		// Tier 3 subs are $25; so subscribers get 5x fun points
-		return 5, nil
+		return SubTier3
+	case "Prime":
+		return SubTierPrime
+	}
+	return SubTierUnknown
+}
+
+// CreditMultiplierPolicy determines how many times the baseline number of fun points a
+// viewer should be credited for a subscription of the given tier
+type CreditMultiplierPolicy interface {
+	CreditMultiplier(tier SubTier) int
+}
+
+// CreditMultiplierTable is a CreditMultiplierPolicy that looks up each tier's
+// multiplier in a table, using Fallback for any tier that's not in the table
+type CreditMultiplierTable struct {
+	Multipliers map[SubTier]int
+	Fallback    int
+}
+
+func (t CreditMultiplierTable) CreditMultiplier(tier SubTier) int {
+	if multiplier, ok := t.Multipliers[tier]; ok {
+		return multiplier
 	}
+	return t.Fallback
+}
+
+// DefaultCreditMultipliers returns the policy that FromEventSub and FromIRC use to
+// populate the CreditMultiplier field of each subscription-related payload, unless a
+// different policy is supplied via WithCreditMultipliers
+func DefaultCreditMultipliers() CreditMultiplierTable {
+	return CreditMultiplierTable{
+		Multipliers: map[SubTier]int{
+			// Tier 1 subs are the baseline at $5; fun points are credited 1x
+			SubTier1: 1,
+			// Prime subs are equivalent to Tier 1 subs
+			SubTierPrime: 1,
+			// Tier 2 subs cost $10; fun point credits are doubled
+			SubTier2: 2,
+			// Tier 3 subs are $25; so subscribers get 5x fun points
+			SubTier3: 5,
+		},
+		// In the event of an unrecognized tier, credit the baseline amount
+		Fallback: 1,
+	}
+}
+
+// ConvertOption configures how FromEventSub and FromIRC convert events from Twitch
+type ConvertOption func(o *convertOptions)
 
-	// In the event of an unrecognized value, fail
-	return 0, fmt.Errorf("unrecognized tier value '%s'", tier)
+type convertOptions struct {
+	creditMultipliers CreditMultiplierPolicy
+}
+
+func newConvertOptions(opts []ConvertOption) convertOptions {
+	o := convertOptions{creditMultipliers: DefaultCreditMultipliers()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithCreditMultipliers supplies the policy used to populate the CreditMultiplier field
+// of each subscription-related payload, in place of DefaultCreditMultipliers
+func WithCreditMultipliers(policy CreditMultiplierPolicy) ConvertOption {
+	return func(o *convertOptions) {
+		o.creditMultipliers = policy
+	}
 }
@@ -0,0 +1,73 @@
+package etwitch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nicklaw5/helix/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseSubTier(t *testing.T) {
+	tests := []struct {
+		tier string
+		want SubTier
+	}{
+		{"1000", SubTier1},
+		{"2000", SubTier2},
+		{"3000", SubTier3},
+		{"Prime", SubTierPrime},
+		{"4000", SubTierUnknown},
+		{"", SubTierUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tier, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseSubTier(tt.tier))
+		})
+	}
+}
+
+func Test_CreditMultiplierTable(t *testing.T) {
+	assert.Equal(t, 1, DefaultCreditMultipliers().CreditMultiplier(SubTier1))
+	assert.Equal(t, 1, DefaultCreditMultipliers().CreditMultiplier(SubTierPrime))
+	assert.Equal(t, 2, DefaultCreditMultipliers().CreditMultiplier(SubTier2))
+	assert.Equal(t, 5, DefaultCreditMultipliers().CreditMultiplier(SubTier3))
+	assert.Equal(t, 1, DefaultCreditMultipliers().CreditMultiplier(SubTierUnknown))
+
+	policy := CreditMultiplierTable{
+		Multipliers: map[SubTier]int{SubTier1: 2, SubTier2: 4, SubTier3: 10},
+		Fallback:    3,
+	}
+	assert.Equal(t, 10, policy.CreditMultiplier(SubTier3))
+	assert.Equal(t, 3, policy.CreditMultiplier(SubTierPrime))
+}
+
+func Test_WithCreditMultipliers(t *testing.T) {
+	policy := CreditMultiplierTable{
+		Multipliers: map[SubTier]int{SubTier2: 3},
+		Fallback:    1,
+	}
+	t.Run("FromEventSub", func(t *testing.T) {
+		subscription := &helix.EventSubSubscription{Type: helix.EventSubTypeChannelSubscription}
+		data := json.RawMessage(`{"user_id":"1234","user_name":"Cool_User","tier":"2000","is_gift":false}`)
+
+		ev, err := FromEventSub(subscription, data)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, ev.Payload.ViewerSubscribed.CreditMultiplier)
+
+		ev, err = FromEventSub(subscription, data, WithCreditMultipliers(policy))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, ev.Payload.ViewerSubscribed.CreditMultiplier)
+	})
+	t.Run("FromIRC", func(t *testing.T) {
+		line := "@display-name=ronni;login=ronni;msg-id=sub;msg-param-sub-plan=2000;user-id=12345678 :tmi.twitch.tv USERNOTICE #ronni"
+
+		events, err := FromIRC(line)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, events[0].Payload.ViewerSubscribed.CreditMultiplier)
+
+		events, err = FromIRC(line, WithCreditMultipliers(policy))
+		assert.NoError(t, err)
+		assert.Equal(t, 3, events[0].Payload.ViewerSubscribed.CreditMultiplier)
+	})
+}
@@ -2,6 +2,7 @@ package etwitch
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/golden-vcr/schemas/core"
 )
@@ -9,108 +10,488 @@ import (
 type EventType string
 
 const (
-	EventTypeStreamStarted           EventType = "stream-started"
-	EventTypeStreamEnded             EventType = "stream-ended"
-	EventTypeStreamHypeStarted       EventType = "stream-hype-started"
-	EventTypeViewerFollowed          EventType = "viewer-followed"
-	EventTypeViewerRaided            EventType = "viewer-raided"
-	EventTypeViewerCheered           EventType = "viewer-cheered"
-	EventTypeViewerRedeemedFunPoints EventType = "viewer-redeemed-fun-points"
-	EventTypeViewerSubscribed        EventType = "viewer-subscribed"
-	EventTypeViewerResubscribed      EventType = "viewer-resubscribed"
-	EventTypeViewerReceivedGiftSub   EventType = "viewer-received-gift-sub"
-	EventTypeViewerGiftedSubs        EventType = "viewer-gifted-subs"
+	EventTypeStreamStarted              EventType = "stream-started"
+	EventTypeStreamEnded                EventType = "stream-ended"
+	EventTypeStreamUpdated              EventType = "stream-updated"
+	EventTypeStreamHypeStarted          EventType = "stream-hype-started"
+	EventTypeStreamHypeProgressed       EventType = "stream-hype-progressed"
+	EventTypeStreamHypeEnded            EventType = "stream-hype-ended"
+	EventTypeStreamShoutoutGiven        EventType = "stream-shoutout-given"
+	EventTypeStreamShoutoutReceived     EventType = "stream-shoutout-received"
+	EventTypeStreamPollStarted          EventType = "stream-poll-started"
+	EventTypeStreamPollProgressed       EventType = "stream-poll-progressed"
+	EventTypeStreamPollEnded            EventType = "stream-poll-ended"
+	EventTypeStreamPredictionStarted    EventType = "stream-prediction-started"
+	EventTypeStreamPredictionProgressed EventType = "stream-prediction-progressed"
+	EventTypeStreamPredictionLocked     EventType = "stream-prediction-locked"
+	EventTypeStreamPredictionEnded      EventType = "stream-prediction-ended"
+	EventTypeStreamGoalStarted          EventType = "stream-goal-started"
+	EventTypeStreamGoalProgressed       EventType = "stream-goal-progressed"
+	EventTypeStreamGoalEnded            EventType = "stream-goal-ended"
+	EventTypeViewerFollowed             EventType = "viewer-followed"
+	EventTypeViewerRaided               EventType = "viewer-raided"
+	EventTypeViewerCheered              EventType = "viewer-cheered"
+	EventTypeViewerRedeemedFunPoints    EventType = "viewer-redeemed-fun-points"
+	EventTypeViewerRedeemedReward       EventType = "viewer-redeemed-reward"
+	EventTypeViewerSubscribed           EventType = "viewer-subscribed"
+	EventTypeViewerResubscribed         EventType = "viewer-resubscribed"
+	EventTypeViewerReceivedGiftSub      EventType = "viewer-received-gift-sub"
+	EventTypeViewerGiftedSubs           EventType = "viewer-gifted-subs"
+	EventTypeViewerBanned               EventType = "viewer-banned"
+	EventTypeViewerUnbanned             EventType = "viewer-unbanned"
+	EventTypeViewerMessageDeleted       EventType = "viewer-message-deleted"
 )
 
 // Event is an event that has occurred on Twitch, such as a viewer interaction or a
-// change in the state of the stream
+// change in the state of the stream. If the event was converted from an EventSub
+// notification, EventSubMessageId identifies that notification: since Twitch may
+// deliver the same notification more than once, consumers can use it to discard
+// duplicate events.
 type Event struct {
-	Type    EventType    `json:"type"`
-	Viewer  *core.Viewer `json:"viewer"`
-	Payload *Payload     `json:"payload"`
+	Type              EventType    `json:"type"`
+	Viewer            *core.Viewer `json:"viewer"`
+	Payload           *Payload     `json:"payload"`
+	EventSubMessageId string       `json:"eventsub_message_id,omitempty"`
 }
 
 type Payload struct {
-	ViewerRaided            *PayloadViewerRaided
-	ViewerCheered           *PayloadViewerCheered
-	ViewerRedeemedFunPoints *PayloadViewerRedeemedFunPoints
-	ViewerSubscribed        *PayloadViewerSubscribed
-	ViewerResubscribed      *PayloadViewerResubscribed
-	ViewerReceivedGiftSub   *PayloadViewerReceivedGiftSub
-	ViewerGiftedSubs        *PayloadViewerGiftedSubs
+	StreamStarted              *PayloadStreamStarted
+	StreamEnded                *PayloadStreamEnded
+	StreamUpdated              *PayloadStreamUpdated
+	StreamHypeStarted          *PayloadStreamHypeStarted
+	StreamHypeProgressed       *PayloadStreamHypeProgressed
+	StreamHypeEnded            *PayloadStreamHypeEnded
+	StreamShoutoutGiven        *PayloadStreamShoutoutGiven
+	StreamShoutoutReceived     *PayloadStreamShoutoutReceived
+	StreamPollStarted          *PayloadStreamPollStarted
+	StreamPollProgressed       *PayloadStreamPollProgressed
+	StreamPollEnded            *PayloadStreamPollEnded
+	StreamPredictionStarted    *PayloadStreamPredictionStarted
+	StreamPredictionProgressed *PayloadStreamPredictionProgressed
+	StreamPredictionLocked     *PayloadStreamPredictionLocked
+	StreamPredictionEnded      *PayloadStreamPredictionEnded
+	StreamGoalStarted          *PayloadStreamGoalStarted
+	StreamGoalProgressed       *PayloadStreamGoalProgressed
+	StreamGoalEnded            *PayloadStreamGoalEnded
+	ViewerRaided               *PayloadViewerRaided
+	ViewerCheered              *PayloadViewerCheered
+	ViewerRedeemedFunPoints    *PayloadViewerRedeemedFunPoints
+	ViewerRedeemedReward       *PayloadViewerRedeemedReward
+	ViewerSubscribed           *PayloadViewerSubscribed
+	ViewerResubscribed         *PayloadViewerResubscribed
+	ViewerReceivedGiftSub      *PayloadViewerReceivedGiftSub
+	ViewerGiftedSubs           *PayloadViewerGiftedSubs
+	ViewerBanned               *PayloadViewerBanned
+	ViewerUnbanned             *PayloadViewerUnbanned
+	ViewerMessageDeleted       *PayloadViewerMessageDeleted
 }
 
+// payloadUnion registers every EventType against the Payload field that carries its
+// data, if any
+var payloadUnion = core.NewUnion[EventType, Payload]("type", "payload").
+	Optional(EventTypeStreamStarted, func(p *Payload) any { return &p.StreamStarted }).
+	Optional(EventTypeStreamEnded, func(p *Payload) any { return &p.StreamEnded }).
+	Variant(EventTypeStreamUpdated, func(p *Payload) any { return &p.StreamUpdated }).
+	Optional(EventTypeStreamHypeStarted, func(p *Payload) any { return &p.StreamHypeStarted }).
+	Variant(EventTypeStreamHypeProgressed, func(p *Payload) any { return &p.StreamHypeProgressed }).
+	Variant(EventTypeStreamHypeEnded, func(p *Payload) any { return &p.StreamHypeEnded }).
+	Variant(EventTypeStreamShoutoutGiven, func(p *Payload) any { return &p.StreamShoutoutGiven }).
+	Variant(EventTypeStreamShoutoutReceived, func(p *Payload) any { return &p.StreamShoutoutReceived }).
+	Variant(EventTypeStreamPollStarted, func(p *Payload) any { return &p.StreamPollStarted }).
+	Variant(EventTypeStreamPollProgressed, func(p *Payload) any { return &p.StreamPollProgressed }).
+	Variant(EventTypeStreamPollEnded, func(p *Payload) any { return &p.StreamPollEnded }).
+	Variant(EventTypeStreamPredictionStarted, func(p *Payload) any { return &p.StreamPredictionStarted }).
+	Variant(EventTypeStreamPredictionProgressed, func(p *Payload) any { return &p.StreamPredictionProgressed }).
+	Variant(EventTypeStreamPredictionLocked, func(p *Payload) any { return &p.StreamPredictionLocked }).
+	Variant(EventTypeStreamPredictionEnded, func(p *Payload) any { return &p.StreamPredictionEnded }).
+	Variant(EventTypeStreamGoalStarted, func(p *Payload) any { return &p.StreamGoalStarted }).
+	Variant(EventTypeStreamGoalProgressed, func(p *Payload) any { return &p.StreamGoalProgressed }).
+	Variant(EventTypeStreamGoalEnded, func(p *Payload) any { return &p.StreamGoalEnded }).
+	Empty(EventTypeViewerFollowed).
+	Variant(EventTypeViewerRaided, func(p *Payload) any { return &p.ViewerRaided }).
+	Variant(EventTypeViewerCheered, func(p *Payload) any { return &p.ViewerCheered }).
+	Variant(EventTypeViewerRedeemedFunPoints, func(p *Payload) any { return &p.ViewerRedeemedFunPoints }).
+	Variant(EventTypeViewerRedeemedReward, func(p *Payload) any { return &p.ViewerRedeemedReward }).
+	Variant(EventTypeViewerSubscribed, func(p *Payload) any { return &p.ViewerSubscribed }).
+	Variant(EventTypeViewerResubscribed, func(p *Payload) any { return &p.ViewerResubscribed }).
+	Variant(EventTypeViewerReceivedGiftSub, func(p *Payload) any { return &p.ViewerReceivedGiftSub }).
+	Variant(EventTypeViewerGiftedSubs, func(p *Payload) any { return &p.ViewerGiftedSubs }).
+	Variant(EventTypeViewerBanned, func(p *Payload) any { return &p.ViewerBanned }).
+	Variant(EventTypeViewerUnbanned, func(p *Payload) any { return &p.ViewerUnbanned }).
+	Variant(EventTypeViewerMessageDeleted, func(p *Payload) any { return &p.ViewerMessageDeleted })
+
 func (e *Event) UnmarshalJSON(data []byte) error {
+	return e.unmarshal(data, false)
+}
+
+// UnmarshalJSONStrict decodes an Event in strict mode, failing with core.ErrUnknownType
+// if the event type is not recognized, or with core.ErrMissingPayload if the event type
+// requires a payload and none is present
+func (e *Event) UnmarshalJSONStrict(data []byte) error {
+	return e.unmarshal(data, true)
+}
+
+func (e *Event) unmarshal(data []byte, strict bool) error {
 	type fields struct {
-		Type    EventType       `json:"type"`
-		Viewer  *core.Viewer    `json:"viewer"`
-		Payload json.RawMessage `json:"payload"`
+		Type              EventType       `json:"type"`
+		Viewer            *core.Viewer    `json:"viewer"`
+		Payload           json.RawMessage `json:"payload"`
+		EventSubMessageId string          `json:"eventsub_message_id,omitempty"`
 	}
 	var f fields
-	if err := json.Unmarshal(data, &f); err != nil {
+	if err := core.DecodeJSON(data, &f, strict); err != nil {
 		return err
 	}
 
 	e.Type = f.Type
 	e.Viewer = f.Viewer
-	switch f.Type {
-	case EventTypeViewerRaided:
-		e.Payload = &Payload{}
-		return json.Unmarshal(f.Payload, &e.Payload.ViewerRaided)
-	case EventTypeViewerCheered:
-		e.Payload = &Payload{}
-		return json.Unmarshal(f.Payload, &e.Payload.ViewerCheered)
-	case EventTypeViewerRedeemedFunPoints:
-		e.Payload = &Payload{}
-		return json.Unmarshal(f.Payload, &e.Payload.ViewerRedeemedFunPoints)
-	case EventTypeViewerSubscribed:
-		e.Payload = &Payload{}
-		return json.Unmarshal(f.Payload, &e.Payload.ViewerSubscribed)
-	case EventTypeViewerResubscribed:
-		e.Payload = &Payload{}
-		return json.Unmarshal(f.Payload, &e.Payload.ViewerResubscribed)
-	case EventTypeViewerReceivedGiftSub:
-		e.Payload = &Payload{}
-		return json.Unmarshal(f.Payload, &e.Payload.ViewerReceivedGiftSub)
-	case EventTypeViewerGiftedSubs:
-		e.Payload = &Payload{}
-		return json.Unmarshal(f.Payload, &e.Payload.ViewerGiftedSubs)
+	e.EventSubMessageId = f.EventSubMessageId
+	var payload Payload
+	if err := payloadUnion.Unmarshal(f.Type, f.Payload, &payload, strict); err != nil {
+		return err
+	}
+	if payloadUnion.HasPayload(f.Type) {
+		e.Payload = &payload
 	}
 	return nil
 }
 
 func (p Payload) MarshalJSON() ([]byte, error) {
-	if p.ViewerRaided != nil {
-		return json.Marshal(p.ViewerRaided)
-	}
-	if p.ViewerCheered != nil {
-		return json.Marshal(p.ViewerCheered)
-	}
-	if p.ViewerRedeemedFunPoints != nil {
-		return json.Marshal(p.ViewerRedeemedFunPoints)
+	return payloadUnion.Marshal(p)
+}
+
+func (Payload) Union() core.UnionInfo {
+	return payloadUnion
+}
+
+// PayloadStreamStarted describes a stream that has just gone online, identified by the
+// ID that Twitch has assigned to it, and timestamped with the time at which Twitch
+// considers the stream to have started. Producers that predate this payload publish
+// stream-started events with a null payload, so consumers must tolerate its absence.
+type PayloadStreamStarted struct {
+	BroadcasterId string     `json:"broadcaster_id"`
+	StreamId      string     `json:"stream_id"`
+	StreamType    StreamType `json:"stream_type"`
+	StartedAt     time.Time  `json:"started_at"`
+}
+
+// PayloadStreamEnded identifies the broadcaster whose stream has just gone offline:
+// Twitch does not report the ID of the stream that ended, so consumers must associate
+// this event with the broadcaster's most recent stream-started event. As with
+// PayloadStreamStarted, the payload is null in events from older producers.
+type PayloadStreamEnded struct {
+	BroadcasterId string `json:"broadcaster_id"`
+}
+
+// PayloadStreamUpdated describes the channel metadata that's in effect after the
+// broadcaster has changed the title, category, language, or content classification
+// labels of their stream: all fields are populated, not only those that changed
+type PayloadStreamUpdated struct {
+	BroadcasterId string   `json:"broadcaster_id"`
+	Title         string   `json:"title"`
+	CategoryId    string   `json:"category_id"`
+	CategoryName  string   `json:"category_name"`
+	Language      string   `json:"language"`
+	ContentLabels []string `json:"content_labels"`
+}
+
+// StreamType indicates whether a stream is live or is a replay of prior content, e.g. a
+// rerun
+type StreamType string
+
+const (
+	StreamTypeLive       StreamType = "live"
+	StreamTypePlaylist   StreamType = "playlist"
+	StreamTypeWatchParty StreamType = "watch_party"
+	StreamTypePremiere   StreamType = "premiere"
+	StreamTypeRerun      StreamType = "rerun"
+)
+
+func (StreamType) EnumValues() []string {
+	return []string{
+		string(StreamTypeLive),
+		string(StreamTypePlaylist),
+		string(StreamTypeWatchParty),
+		string(StreamTypePremiere),
+		string(StreamTypeRerun),
 	}
-	if p.ViewerSubscribed != nil {
-		return json.Marshal(p.ViewerSubscribed)
+}
+
+// PayloadStreamHypeStarted describes the state of a hype train when it begins: Progress
+// counts the points contributed toward Goal, which must be reached before ExpiresAt in
+// order for the hype train to advance beyond its current Level. Producers that predate
+// this payload publish stream-hype-started events with a null payload.
+type PayloadStreamHypeStarted struct {
+	Level            int                `json:"level"`
+	Total            int                `json:"total"`
+	Progress         int                `json:"progress"`
+	Goal             int                `json:"goal"`
+	TopContributions []HypeContribution `json:"top_contributions"`
+	ExpiresAt        time.Time          `json:"expires_at"`
+}
+
+// PayloadStreamHypeProgressed describes the state of an ongoing hype train after a
+// viewer has contributed to it
+type PayloadStreamHypeProgressed struct {
+	Level            int                `json:"level"`
+	Total            int                `json:"total"`
+	Progress         int                `json:"progress"`
+	Goal             int                `json:"goal"`
+	TopContributions []HypeContribution `json:"top_contributions"`
+	ExpiresAt        time.Time          `json:"expires_at"`
+}
+
+// PayloadStreamHypeEnded describes the final state of a hype train, along with the time
+// at which the cooldown period ends and another hype train may begin
+type PayloadStreamHypeEnded struct {
+	Level            int                `json:"level"`
+	Total            int                `json:"total"`
+	TopContributions []HypeContribution `json:"top_contributions"`
+	CooldownEndsAt   time.Time          `json:"cooldown_ends_at"`
+}
+
+// HypeContribution records the points that a single viewer has contributed to a hype
+// train by means of a particular type of contribution
+type HypeContribution struct {
+	Viewer core.Viewer          `json:"viewer"`
+	Type   HypeContributionType `json:"type"`
+	Total  int                  `json:"total"`
+}
+
+// HypeContributionType identifies how a viewer contributed to a hype train
+type HypeContributionType string
+
+const (
+	HypeContributionTypeBits         HypeContributionType = "bits"
+	HypeContributionTypeSubscription HypeContributionType = "subscription"
+	HypeContributionTypeOther        HypeContributionType = "other"
+)
+
+func (HypeContributionType) EnumValues() []string {
+	return []string{
+		string(HypeContributionTypeBits),
+		string(HypeContributionTypeSubscription),
+		string(HypeContributionTypeOther),
 	}
-	if p.ViewerResubscribed != nil {
-		return json.Marshal(p.ViewerResubscribed)
+}
+
+// PayloadStreamShoutoutGiven describes a shoutout that we've given to another
+// broadcaster, as issued by the broadcaster or one of their moderators: NumViewers is
+// the number of viewers who saw the shoutout, CooldownEndsAt is the earliest time at
+// which we may give another shoutout, and TargetCooldownEndsAt is the earliest time at
+// which we may shout out the same broadcaster again
+type PayloadStreamShoutoutGiven struct {
+	Broadcaster          core.Viewer `json:"broadcaster"`
+	Moderator            core.Viewer `json:"moderator"`
+	NumViewers           int         `json:"num_viewers"`
+	StartedAt            time.Time   `json:"started_at"`
+	CooldownEndsAt       time.Time   `json:"cooldown_ends_at"`
+	TargetCooldownEndsAt time.Time   `json:"target_cooldown_ends_at"`
+}
+
+// PayloadStreamShoutoutReceived describes a shoutout that another broadcaster has given
+// to us, along with the number of viewers who saw it in that broadcaster's channel
+type PayloadStreamShoutoutReceived struct {
+	Broadcaster core.Viewer `json:"broadcaster"`
+	NumViewers  int         `json:"num_viewers"`
+	StartedAt   time.Time   `json:"started_at"`
+}
+
+// PayloadStreamPollStarted describes a poll that has just begun: viewers may vote until
+// EndsAt. If viewers may spend channel points to cast additional votes,
+// ChannelPointsPerVote is the cost of each additional vote; otherwise it's 0.
+type PayloadStreamPollStarted struct {
+	PollId               string            `json:"poll_id"`
+	Title                string            `json:"title"`
+	Choices              []core.PollChoice `json:"choices"`
+	ChannelPointsPerVote int               `json:"channel_points_per_vote"`
+	StartedAt            time.Time         `json:"started_at"`
+	EndsAt               time.Time         `json:"ends_at"`
+}
+
+// PayloadStreamPollProgressed describes the state of an ongoing poll after viewers have
+// voted in it
+type PayloadStreamPollProgressed struct {
+	PollId               string            `json:"poll_id"`
+	Title                string            `json:"title"`
+	Choices              []core.PollChoice `json:"choices"`
+	ChannelPointsPerVote int               `json:"channel_points_per_vote"`
+	StartedAt            time.Time         `json:"started_at"`
+	EndsAt               time.Time         `json:"ends_at"`
+}
+
+// PayloadStreamPollEnded describes the final tally of a poll, along with the reason it
+// ended
+type PayloadStreamPollEnded struct {
+	PollId               string            `json:"poll_id"`
+	Title                string            `json:"title"`
+	Choices              []core.PollChoice `json:"choices"`
+	ChannelPointsPerVote int               `json:"channel_points_per_vote"`
+	Status               PollStatus        `json:"status"`
+	StartedAt            time.Time         `json:"started_at"`
+	EndedAt              time.Time         `json:"ended_at"`
+}
+
+// PollStatus indicates why a poll ended: polls are completed when they run their
+// course, terminated when they're ended early, and archived when they're completed or
+// terminated and then hidden from view
+type PollStatus string
+
+const (
+	PollStatusCompleted  PollStatus = "completed"
+	PollStatusTerminated PollStatus = "terminated"
+	PollStatusArchived   PollStatus = "archived"
+)
+
+func (PollStatus) EnumValues() []string {
+	return []string{
+		string(PollStatusCompleted),
+		string(PollStatusTerminated),
+		string(PollStatusArchived),
 	}
-	if p.ViewerReceivedGiftSub != nil {
-		return json.Marshal(p.ViewerReceivedGiftSub)
+}
+
+// PayloadStreamPredictionStarted describes a prediction that has just begun: viewers
+// may wager channel points on its outcomes until LocksAt
+type PayloadStreamPredictionStarted struct {
+	PredictionId string              `json:"prediction_id"`
+	Title        string              `json:"title"`
+	Outcomes     []PredictionOutcome `json:"outcomes"`
+	StartedAt    time.Time           `json:"started_at"`
+	LocksAt      time.Time           `json:"locks_at"`
+}
+
+// PayloadStreamPredictionProgressed describes the state of an ongoing prediction after
+// viewers have wagered channel points on it
+type PayloadStreamPredictionProgressed struct {
+	PredictionId string              `json:"prediction_id"`
+	Title        string              `json:"title"`
+	Outcomes     []PredictionOutcome `json:"outcomes"`
+	StartedAt    time.Time           `json:"started_at"`
+	LocksAt      time.Time           `json:"locks_at"`
+}
+
+// PayloadStreamPredictionLocked describes a prediction that is no longer accepting
+// wagers, but whose outcome has not yet been decided
+type PayloadStreamPredictionLocked struct {
+	PredictionId string              `json:"prediction_id"`
+	Title        string              `json:"title"`
+	Outcomes     []PredictionOutcome `json:"outcomes"`
+	StartedAt    time.Time           `json:"started_at"`
+	LockedAt     time.Time           `json:"locked_at"`
+}
+
+// PayloadStreamPredictionEnded describes the final state of a prediction: if it was
+// resolved, WinningOutcomeId identifies the outcome whose predictors won their wagers;
+// if it was canceled, WinningOutcomeId is empty and all wagers were refunded
+type PayloadStreamPredictionEnded struct {
+	PredictionId     string              `json:"prediction_id"`
+	Title            string              `json:"title"`
+	Outcomes         []PredictionOutcome `json:"outcomes"`
+	WinningOutcomeId string              `json:"winning_outcome_id"`
+	Status           PredictionStatus    `json:"status"`
+	StartedAt        time.Time           `json:"started_at"`
+	EndedAt          time.Time           `json:"ended_at"`
+}
+
+// PredictionOutcome records the channel points wagered on one of the possible outcomes
+// of a prediction, by NumUsers viewers in total, along with the viewers who wagered
+// the most
+type PredictionOutcome struct {
+	Id               string                 `json:"id"`
+	Title            string                 `json:"title"`
+	Color            PredictionOutcomeColor `json:"color"`
+	NumUsers         int                    `json:"num_users"`
+	NumChannelPoints int                    `json:"num_channel_points"`
+	TopPredictors    []Predictor            `json:"top_predictors"`
+}
+
+// PredictionOutcomeColor is the color in which Twitch displays a prediction outcome
+type PredictionOutcomeColor string
+
+const (
+	PredictionOutcomeColorBlue PredictionOutcomeColor = "blue"
+	PredictionOutcomeColorPink PredictionOutcomeColor = "pink"
+)
+
+func (PredictionOutcomeColor) EnumValues() []string {
+	return []string{
+		string(PredictionOutcomeColorBlue),
+		string(PredictionOutcomeColorPink),
 	}
-	if p.ViewerGiftedSubs != nil {
-		return json.Marshal(p.ViewerGiftedSubs)
+}
+
+// Predictor records the channel points that a viewer has wagered on a prediction
+// outcome: ChannelPointsWon is 0 until the prediction is resolved, and remains 0 if
+// the outcome did not win
+type Predictor struct {
+	Viewer            core.Viewer `json:"viewer"`
+	ChannelPointsUsed int         `json:"channel_points_used"`
+	ChannelPointsWon  int         `json:"channel_points_won"`
+}
+
+// PredictionStatus indicates whether a prediction was resolved with a winning outcome,
+// or canceled with all wagers refunded
+type PredictionStatus string
+
+const (
+	PredictionStatusResolved PredictionStatus = "resolved"
+	PredictionStatusCanceled PredictionStatus = "canceled"
+)
+
+func (PredictionStatus) EnumValues() []string {
+	return []string{
+		string(PredictionStatusResolved),
+		string(PredictionStatusCanceled),
 	}
-	return json.Marshal(nil)
+}
+
+// PayloadStreamGoalStarted describes a creator goal that the broadcaster has just set:
+// CurrentAmount counts progress toward TargetAmount, in units determined by the goal's
+// Type (e.g. followers, subscriptions, or bits)
+type PayloadStreamGoalStarted struct {
+	GoalId        string        `json:"goal_id"`
+	Type          core.GoalType `json:"type"`
+	Description   string        `json:"description"`
+	CurrentAmount int           `json:"current_amount"`
+	TargetAmount  int           `json:"target_amount"`
+	StartedAt     time.Time     `json:"started_at"`
+}
+
+// PayloadStreamGoalProgressed describes the state of a creator goal after progress has
+// been made toward it
+type PayloadStreamGoalProgressed struct {
+	GoalId        string        `json:"goal_id"`
+	Type          core.GoalType `json:"type"`
+	Description   string        `json:"description"`
+	CurrentAmount int           `json:"current_amount"`
+	TargetAmount  int           `json:"target_amount"`
+	StartedAt     time.Time     `json:"started_at"`
+}
+
+// PayloadStreamGoalEnded describes the final state of a creator goal, indicating
+// whether the goal's target was reached before the broadcaster ended it
+type PayloadStreamGoalEnded struct {
+	GoalId        string        `json:"goal_id"`
+	Type          core.GoalType `json:"type"`
+	Description   string        `json:"description"`
+	CurrentAmount int           `json:"current_amount"`
+	TargetAmount  int           `json:"target_amount"`
+	IsAchieved    bool          `json:"is_achieved"`
+	StartedAt     time.Time     `json:"started_at"`
+	EndedAt       time.Time     `json:"ended_at"`
 }
 
 type PayloadViewerRaided struct {
 	NumRaiders int `json:"num_raiders"`
 }
 
+// PayloadViewerCheered describes a cheer: Message is the viewer's message verbatim,
+// while MessageFragments identifies the emotes and cheermotes within it
 type PayloadViewerCheered struct {
-	NumBits int    `json:"num_bits"`
-	Message string `json:"message"`
+	NumBits          int              `json:"num_bits"`
+	Message          string           `json:"message"`
+	MessageFragments MessageFragments `json:"message_fragments,omitempty"`
 }
 
 type PayloadViewerRedeemedFunPoints struct {
@@ -118,21 +499,57 @@ type PayloadViewerRedeemedFunPoints struct {
 	Message   string `json:"message"`
 }
 
+// PayloadViewerRedeemedReward describes a viewer's redemption of a custom channel
+// points reward, identifying the reward along with its cost in channel points, and
+// carrying any text that the viewer entered when redeeming it
+type PayloadViewerRedeemedReward struct {
+	RewardId    string `json:"reward_id"`
+	RewardTitle string `json:"reward_title"`
+	RewardCost  int    `json:"reward_cost"`
+	Message     string `json:"message"`
+}
+
 type PayloadViewerSubscribed struct {
-	CreditMultiplier int `json:"credit_multiplier"`
+	Tier             SubTier `json:"tier,omitempty"`
+	CreditMultiplier int     `json:"credit_multiplier"`
 }
 
 type PayloadViewerResubscribed struct {
-	CreditMultiplier    int    `json:"credit_multiplier"`
-	NumCumulativeMonths int    `json:"num_cumulative_months"`
-	Message             string `json:"message"`
+	Tier                SubTier          `json:"tier,omitempty"`
+	CreditMultiplier    int              `json:"credit_multiplier"`
+	NumCumulativeMonths int              `json:"num_cumulative_months"`
+	Message             string           `json:"message"`
+	MessageFragments    MessageFragments `json:"message_fragments,omitempty"`
 }
 
 type PayloadViewerReceivedGiftSub struct {
-	CreditMultiplier int `json:"credit_multiplier"`
+	Tier             SubTier `json:"tier,omitempty"`
+	CreditMultiplier int     `json:"credit_multiplier"`
 }
 
 type PayloadViewerGiftedSubs struct {
-	CreditMultiplier int `json:"credit_multiplier"`
-	NumSubscriptions int `json:"num_subscriptions"`
+	Tier             SubTier `json:"tier,omitempty"`
+	CreditMultiplier int     `json:"credit_multiplier"`
+	NumSubscriptions int     `json:"num_subscriptions"`
+}
+
+// PayloadViewerBanned describes a moderator's decision to ban the viewer from the chat:
+// if the ban is a timeout, ExpiresAt records when it ends; if it's permanent, ExpiresAt
+// is nil. Moderator is nil if the source of the event doesn't identify the moderator,
+// as is the case with Twitch chat's CLEARCHAT messages.
+type PayloadViewerBanned struct {
+	Moderator *core.Viewer `json:"moderator"`
+	Reason    string       `json:"reason"`
+	ExpiresAt *time.Time   `json:"expires_at"`
+}
+
+// PayloadViewerUnbanned identifies the moderator who lifted a viewer's ban
+type PayloadViewerUnbanned struct {
+	Moderator core.Viewer `json:"moderator"`
+}
+
+// PayloadViewerMessageDeleted identifies a chat message, sent by the viewer, that was
+// deleted by a moderator: Twitch does not report which moderator deleted it
+type PayloadViewerMessageDeleted struct {
+	MessageId string `json:"message_id"`
 }
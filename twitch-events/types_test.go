@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golden-vcr/schemas/core"
 	"github.com/stretchr/testify/assert"
@@ -19,15 +20,86 @@ func Test_Event(t *testing.T) {
 			"stream started event",
 			Event{
 				Type: EventTypeStreamStarted,
+				Payload: &Payload{
+					StreamStarted: &PayloadStreamStarted{
+						BroadcasterId: "953753877",
+						StreamId:      "40234719239",
+						StreamType:    StreamTypeRerun,
+						StartedAt:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					},
+				},
 			},
-			`{"type":"stream-started","viewer":null,"payload":null}`,
+			`{"type":"stream-started","viewer":null,"payload":{"broadcaster_id":"953753877","stream_id":"40234719239","stream_type":"rerun","started_at":"2024-01-02T03:04:05Z"}}`,
 		},
 		{
 			"stream ended event",
 			Event{
 				Type: EventTypeStreamEnded,
+				Payload: &Payload{
+					StreamEnded: &PayloadStreamEnded{BroadcasterId: "953753877"},
+				},
 			},
-			`{"type":"stream-ended","viewer":null,"payload":null}`,
+			`{"type":"stream-ended","viewer":null,"payload":{"broadcaster_id":"953753877"}}`,
+		},
+		{
+			"stream updated event",
+			Event{
+				Type: EventTypeStreamUpdated,
+				Payload: &Payload{
+					StreamUpdated: &PayloadStreamUpdated{
+						BroadcasterId: "953753877",
+						Title:         "Golden VCR: watching a tape about seals",
+						CategoryId:    "509658",
+						CategoryName:  "Just Chatting",
+						Language:      "en",
+						ContentLabels: []string{},
+					},
+				},
+			},
+			`{"type":"stream-updated","viewer":null,"payload":{"broadcaster_id":"953753877","title":"Golden VCR: watching a tape about seals","category_id":"509658","category_name":"Just Chatting","language":"en","content_labels":[]}}`,
+		},
+		{
+			"stream hype progressed event",
+			Event{
+				Type: EventTypeStreamHypeProgressed,
+				Payload: &Payload{
+					StreamHypeProgressed: &PayloadStreamHypeProgressed{
+						Level:    3,
+						Total:    1200,
+						Progress: 150,
+						Goal:     1800,
+						TopContributions: []HypeContribution{
+							{
+								Viewer: core.Viewer{
+									TwitchUserId:      "90790024",
+									TwitchDisplayName: "wasabimilkshake",
+								},
+								Type:  HypeContributionTypeBits,
+								Total: 500,
+							},
+						},
+						ExpiresAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					},
+				},
+			},
+			`{"type":"stream-hype-progressed","viewer":null,"payload":{"level":3,"total":1200,"progress":150,"goal":1800,"top_contributions":[{"viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"type":"bits","total":500}],"expires_at":"2024-01-02T03:04:05Z"}}`,
+		},
+		{
+			"stream shoutout received event",
+			Event{
+				Type: EventTypeStreamShoutoutReceived,
+				Payload: &Payload{
+					StreamShoutoutReceived: &PayloadStreamShoutoutReceived{
+						Broadcaster: core.Viewer{
+							TwitchUserId:      "90790024",
+							TwitchDisplayName: "wasabimilkshake",
+						},
+						NumViewers: 41,
+						StartedAt:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					},
+				},
+			},
+			`{"type":"stream-shoutout-received","viewer":null,"payload":{"broadcaster":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"num_viewers":41,"started_at":"2024-01-02T03:04:05Z"}}`,
 		},
 		{
 			"viewer followed event",
@@ -67,11 +139,22 @@ func Test_Event(t *testing.T) {
 				Payload: &Payload{
 					ViewerCheered: &PayloadViewerCheered{
 						NumBits: 200,
-						Message: "ghost of a seal",
+						Message: "Cheer200 ghost of a seal",
+						MessageFragments: MessageFragments{
+							{
+								Type:      MessageFragmentTypeCheermote,
+								Text:      "Cheer200",
+								Cheermote: &MessageCheermote{Prefix: "Cheer", NumBits: 200},
+							},
+							{
+								Type: MessageFragmentTypeText,
+								Text: " ghost of a seal",
+							},
+						},
 					},
 				},
 			},
-			`{"type":"viewer-cheered","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"num_bits":200,"message":"ghost of a seal"}}`,
+			`{"type":"viewer-cheered","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"num_bits":200,"message":"Cheer200 ghost of a seal","message_fragments":[{"type":"cheermote","text":"Cheer200","emote":null,"cheermote":{"prefix":"Cheer","num_bits":200}},{"type":"text","text":" ghost of a seal","emote":null,"cheermote":null}]}}`,
 		},
 		{
 			"viewer cheered event (anonymous)",
@@ -103,6 +186,46 @@ func Test_Event(t *testing.T) {
 			},
 			`{"type":"viewer-redeemed-fun-points","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"num_points":200,"message":"ghost of a seal"}}`,
 		},
+		{
+			"viewer redeemed reward event",
+			Event{
+				Type: EventTypeViewerRedeemedReward,
+				Viewer: &core.Viewer{
+					TwitchUserId:      "90790024",
+					TwitchDisplayName: "wasabimilkshake",
+				},
+				Payload: &Payload{
+					ViewerRedeemedReward: &PayloadViewerRedeemedReward{
+						RewardId:    "92af127c-7326-4483-a52b-b0da0be61c01",
+						RewardTitle: "Summon a ghost",
+						RewardCost:  500,
+						Message:     "ghost of a seal",
+					},
+				},
+			},
+			`{"type":"viewer-redeemed-reward","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"reward_id":"92af127c-7326-4483-a52b-b0da0be61c01","reward_title":"Summon a ghost","reward_cost":500,"message":"ghost of a seal"}}`,
+		},
+		{
+			"viewer banned event",
+			Event{
+				Type: EventTypeViewerBanned,
+				Viewer: &core.Viewer{
+					TwitchUserId:      "7734",
+					TwitchDisplayName: "Uneasy_Seal",
+				},
+				Payload: &Payload{
+					ViewerBanned: &PayloadViewerBanned{
+						Moderator: &core.Viewer{
+							TwitchUserId:      "90790024",
+							TwitchDisplayName: "wasabimilkshake",
+						},
+						Reason:    "spam",
+						ExpiresAt: func() *time.Time { t := time.Date(2024, 1, 2, 3, 14, 5, 0, time.UTC); return &t }(),
+					},
+				},
+			},
+			`{"type":"viewer-banned","viewer":{"twitch_user_id":"7734","twitch_display_name":"Uneasy_Seal"},"payload":{"moderator":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"reason":"spam","expires_at":"2024-01-02T03:14:05Z"}}`,
+		},
 		{
 			"viewer subscribed event",
 			Event{
@@ -113,11 +236,12 @@ func Test_Event(t *testing.T) {
 				},
 				Payload: &Payload{
 					ViewerSubscribed: &PayloadViewerSubscribed{
+						Tier:             SubTier1,
 						CreditMultiplier: 1,
 					},
 				},
 			},
-			`{"type":"viewer-subscribed","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"credit_multiplier":1}}`,
+			`{"type":"viewer-subscribed","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"tier":"1000","credit_multiplier":1}}`,
 		},
 		{
 			"viewer resubscribed event",
@@ -129,13 +253,14 @@ func Test_Event(t *testing.T) {
 				},
 				Payload: &Payload{
 					ViewerResubscribed: &PayloadViewerResubscribed{
+						Tier:                SubTierPrime,
 						CreditMultiplier:    1,
 						NumCumulativeMonths: 3,
 						Message:             "good job",
 					},
 				},
 			},
-			`{"type":"viewer-resubscribed","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"credit_multiplier":1,"num_cumulative_months":3,"message":"good job"}}`,
+			`{"type":"viewer-resubscribed","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"tier":"prime","credit_multiplier":1,"num_cumulative_months":3,"message":"good job"}}`,
 		},
 		{
 			"viewer received gift sub event",
@@ -147,11 +272,12 @@ func Test_Event(t *testing.T) {
 				},
 				Payload: &Payload{
 					ViewerReceivedGiftSub: &PayloadViewerReceivedGiftSub{
+						Tier:             SubTier1,
 						CreditMultiplier: 1,
 					},
 				},
 			},
-			`{"type":"viewer-received-gift-sub","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"credit_multiplier":1}}`,
+			`{"type":"viewer-received-gift-sub","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"tier":"1000","credit_multiplier":1}}`,
 		},
 		{
 			"viewer gifted subs event",
@@ -163,12 +289,13 @@ func Test_Event(t *testing.T) {
 				},
 				Payload: &Payload{
 					ViewerGiftedSubs: &PayloadViewerGiftedSubs{
+						Tier:             SubTier1,
 						CreditMultiplier: 1,
 						NumSubscriptions: 5,
 					},
 				},
 			},
-			`{"type":"viewer-gifted-subs","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"credit_multiplier":1,"num_subscriptions":5}}`,
+			`{"type":"viewer-gifted-subs","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":{"tier":"1000","credit_multiplier":1,"num_subscriptions":5}}`,
 		},
 		{
 			"viewer gifted subs event (anonymous)",
@@ -176,12 +303,25 @@ func Test_Event(t *testing.T) {
 				Type: EventTypeViewerGiftedSubs,
 				Payload: &Payload{
 					ViewerGiftedSubs: &PayloadViewerGiftedSubs{
+						Tier:             SubTier1,
 						CreditMultiplier: 1,
 						NumSubscriptions: 5,
 					},
 				},
 			},
-			`{"type":"viewer-gifted-subs","viewer":null,"payload":{"credit_multiplier":1,"num_subscriptions":5}}`,
+			`{"type":"viewer-gifted-subs","viewer":null,"payload":{"tier":"1000","credit_multiplier":1,"num_subscriptions":5}}`,
+		},
+		{
+			"viewer followed event from EventSub notification",
+			Event{
+				Type: EventTypeViewerFollowed,
+				Viewer: &core.Viewer{
+					TwitchUserId:      "90790024",
+					TwitchDisplayName: "wasabimilkshake",
+				},
+				EventSubMessageId: "befa7b53-d79d-478f-86b9-120f112b044e",
+			},
+			`{"type":"viewer-followed","viewer":{"twitch_user_id":"90790024","twitch_display_name":"wasabimilkshake"},"payload":null,"eventsub_message_id":"befa7b53-d79d-478f-86b9-120f112b044e"}`,
 		},
 	}
 	for _, tt := range tests {
@@ -200,3 +340,7 @@ func Test_Event(t *testing.T) {
 		})
 	}
 }
+
+func Test_unions(t *testing.T) {
+	assert.NoError(t, payloadUnion.Check())
+}
@@ -0,0 +1,405 @@
+package etwitch
+
+import (
+	"fmt"
+
+	"github.com/golden-vcr/schemas/core"
+)
+
+// Validate verifies that an Event is well-formed: its type must be recognized, it must
+// carry the payload required by that type (and no other), and it must identify a viewer
+// unless the event type permits anonymity
+func (e Event) Validate() error {
+	var v core.Validator
+	payload := e.Payload
+	if payload == nil {
+		payload = &Payload{}
+	}
+	v.Nested("", payloadUnion.Validate(e.Type, payload))
+	if e.Viewer != nil {
+		v.Nested("/viewer", e.Viewer.Validate())
+	} else {
+		v.Check(!requiresViewer(e.Type), "/viewer", "is required")
+	}
+	return v.Err()
+}
+
+// requiresViewer returns true if events of the given type are always attributed to a
+// specific viewer
+func requiresViewer(t EventType) bool {
+	switch t {
+	case EventTypeStreamStarted, EventTypeStreamEnded, EventTypeStreamUpdated:
+		return false
+	case EventTypeStreamHypeStarted, EventTypeStreamHypeProgressed, EventTypeStreamHypeEnded:
+		return false
+	case EventTypeStreamShoutoutGiven, EventTypeStreamShoutoutReceived:
+		return false
+	case EventTypeStreamPollStarted, EventTypeStreamPollProgressed, EventTypeStreamPollEnded:
+		return false
+	case EventTypeStreamPredictionStarted, EventTypeStreamPredictionProgressed:
+		return false
+	case EventTypeStreamPredictionLocked, EventTypeStreamPredictionEnded:
+		return false
+	case EventTypeStreamGoalStarted, EventTypeStreamGoalProgressed, EventTypeStreamGoalEnded:
+		return false
+	case EventTypeViewerCheered, EventTypeViewerGiftedSubs:
+		return false
+	}
+	return true
+}
+
+func (p PayloadStreamStarted) Validate() error {
+	var v core.Validator
+	v.Check(p.BroadcasterId != "", "/broadcaster_id", "is required")
+	v.Check(p.StreamId != "", "/stream_id", "is required")
+	v.Nested("/stream_type", p.StreamType.Validate())
+	v.Check(!p.StartedAt.IsZero(), "/started_at", "is required")
+	return v.Err()
+}
+
+func (p PayloadStreamEnded) Validate() error {
+	var v core.Validator
+	v.Check(p.BroadcasterId != "", "/broadcaster_id", "is required")
+	return v.Err()
+}
+
+func (p PayloadStreamUpdated) Validate() error {
+	var v core.Validator
+	v.Check(p.BroadcasterId != "", "/broadcaster_id", "is required")
+	return v.Err()
+}
+
+// Validate verifies that a StreamType is one of the types recognized by Twitch
+func (t StreamType) Validate() error {
+	switch t {
+	case StreamTypeLive, StreamTypePlaylist, StreamTypeWatchParty, StreamTypePremiere, StreamTypeRerun:
+		return nil
+	}
+	return fmt.Errorf("'%s' is not a valid stream type", t)
+}
+
+func (p PayloadStreamHypeStarted) Validate() error {
+	var v core.Validator
+	v.Check(p.Level > 0, "/level", "must be a positive number")
+	v.Check(p.Goal > 0, "/goal", "must be a positive number")
+	v.Check(p.Progress >= 0, "/progress", "must not be negative")
+	validateHypeContributions(&v, p.TopContributions)
+	return v.Err()
+}
+
+func (p PayloadStreamHypeProgressed) Validate() error {
+	var v core.Validator
+	v.Check(p.Level > 0, "/level", "must be a positive number")
+	v.Check(p.Goal > 0, "/goal", "must be a positive number")
+	v.Check(p.Progress >= 0, "/progress", "must not be negative")
+	validateHypeContributions(&v, p.TopContributions)
+	return v.Err()
+}
+
+func (p PayloadStreamHypeEnded) Validate() error {
+	var v core.Validator
+	v.Check(p.Level > 0, "/level", "must be a positive number")
+	validateHypeContributions(&v, p.TopContributions)
+	return v.Err()
+}
+
+func validateHypeContributions(v *core.Validator, contributions []HypeContribution) {
+	for i, c := range contributions {
+		v.Nested(fmt.Sprintf("/top_contributions/%d", i), c.Validate())
+	}
+}
+
+func (c HypeContribution) Validate() error {
+	var v core.Validator
+	v.Nested("/viewer", c.Viewer.Validate())
+	v.Nested("/type", c.Type.Validate())
+	v.Check(c.Total > 0, "/total", "must be a positive number")
+	return v.Err()
+}
+
+// Validate verifies that a HypeContributionType is one of the types recognized by
+// Twitch
+func (t HypeContributionType) Validate() error {
+	switch t {
+	case HypeContributionTypeBits, HypeContributionTypeSubscription, HypeContributionTypeOther:
+		return nil
+	}
+	return fmt.Errorf("'%s' is not a valid hype contribution type", t)
+}
+
+func (p PayloadStreamShoutoutGiven) Validate() error {
+	var v core.Validator
+	v.Nested("/broadcaster", p.Broadcaster.Validate())
+	v.Nested("/moderator", p.Moderator.Validate())
+	v.Check(p.NumViewers >= 0, "/num_viewers", "must not be negative")
+	v.Check(!p.StartedAt.IsZero(), "/started_at", "is required")
+	return v.Err()
+}
+
+func (p PayloadStreamShoutoutReceived) Validate() error {
+	var v core.Validator
+	v.Nested("/broadcaster", p.Broadcaster.Validate())
+	v.Check(p.NumViewers >= 0, "/num_viewers", "must not be negative")
+	v.Check(!p.StartedAt.IsZero(), "/started_at", "is required")
+	return v.Err()
+}
+
+func (p PayloadStreamPollStarted) Validate() error {
+	var v core.Validator
+	v.Check(p.PollId != "", "/poll_id", "is required")
+	validatePollChoices(&v, p.Choices)
+	v.Check(p.ChannelPointsPerVote >= 0, "/channel_points_per_vote", "must not be negative")
+	return v.Err()
+}
+
+func (p PayloadStreamPollProgressed) Validate() error {
+	var v core.Validator
+	v.Check(p.PollId != "", "/poll_id", "is required")
+	validatePollChoices(&v, p.Choices)
+	v.Check(p.ChannelPointsPerVote >= 0, "/channel_points_per_vote", "must not be negative")
+	return v.Err()
+}
+
+func (p PayloadStreamPollEnded) Validate() error {
+	var v core.Validator
+	v.Check(p.PollId != "", "/poll_id", "is required")
+	validatePollChoices(&v, p.Choices)
+	v.Check(p.ChannelPointsPerVote >= 0, "/channel_points_per_vote", "must not be negative")
+	v.Nested("/status", p.Status.Validate())
+	return v.Err()
+}
+
+func validatePollChoices(v *core.Validator, choices []core.PollChoice) {
+	v.Check(len(choices) > 0, "/choices", "must not be empty")
+	for i, c := range choices {
+		v.Nested(fmt.Sprintf("/choices/%d", i), c.Validate())
+	}
+}
+
+// Validate verifies that a PollStatus is one of the statuses with which Twitch reports
+// that a poll has ended
+func (s PollStatus) Validate() error {
+	switch s {
+	case PollStatusCompleted, PollStatusTerminated, PollStatusArchived:
+		return nil
+	}
+	return fmt.Errorf("'%s' is not a valid poll status", s)
+}
+
+func (p PayloadStreamPredictionStarted) Validate() error {
+	var v core.Validator
+	v.Check(p.PredictionId != "", "/prediction_id", "is required")
+	validatePredictionOutcomes(&v, p.Outcomes)
+	return v.Err()
+}
+
+func (p PayloadStreamPredictionProgressed) Validate() error {
+	var v core.Validator
+	v.Check(p.PredictionId != "", "/prediction_id", "is required")
+	validatePredictionOutcomes(&v, p.Outcomes)
+	return v.Err()
+}
+
+func (p PayloadStreamPredictionLocked) Validate() error {
+	var v core.Validator
+	v.Check(p.PredictionId != "", "/prediction_id", "is required")
+	validatePredictionOutcomes(&v, p.Outcomes)
+	return v.Err()
+}
+
+func (p PayloadStreamPredictionEnded) Validate() error {
+	var v core.Validator
+	v.Check(p.PredictionId != "", "/prediction_id", "is required")
+	validatePredictionOutcomes(&v, p.Outcomes)
+	v.Nested("/status", p.Status.Validate())
+	if p.Status == PredictionStatusResolved {
+		v.Check(p.WinningOutcomeId != "", "/winning_outcome_id", "is required")
+	} else {
+		v.Check(p.WinningOutcomeId == "", "/winning_outcome_id", "must be empty")
+	}
+	return v.Err()
+}
+
+func validatePredictionOutcomes(v *core.Validator, outcomes []PredictionOutcome) {
+	v.Check(len(outcomes) > 0, "/outcomes", "must not be empty")
+	for i, o := range outcomes {
+		v.Nested(fmt.Sprintf("/outcomes/%d", i), o.Validate())
+	}
+}
+
+func (o PredictionOutcome) Validate() error {
+	var v core.Validator
+	v.Check(o.Id != "", "/id", "is required")
+	v.Nested("/color", o.Color.Validate())
+	v.Check(o.NumUsers >= 0, "/num_users", "must not be negative")
+	v.Check(o.NumChannelPoints >= 0, "/num_channel_points", "must not be negative")
+	for i, p := range o.TopPredictors {
+		v.Nested(fmt.Sprintf("/top_predictors/%d", i), p.Validate())
+	}
+	return v.Err()
+}
+
+// Validate verifies that a PredictionOutcomeColor is one of the colors used by Twitch
+func (c PredictionOutcomeColor) Validate() error {
+	switch c {
+	case PredictionOutcomeColorBlue, PredictionOutcomeColorPink:
+		return nil
+	}
+	return fmt.Errorf("'%s' is not a valid prediction outcome color", c)
+}
+
+func (p Predictor) Validate() error {
+	var v core.Validator
+	v.Nested("/viewer", p.Viewer.Validate())
+	v.Check(p.ChannelPointsUsed > 0, "/channel_points_used", "must be a positive number")
+	v.Check(p.ChannelPointsWon >= 0, "/channel_points_won", "must not be negative")
+	return v.Err()
+}
+
+// Validate verifies that a PredictionStatus is one of the statuses with which Twitch
+// reports that a prediction has ended
+func (s PredictionStatus) Validate() error {
+	switch s {
+	case PredictionStatusResolved, PredictionStatusCanceled:
+		return nil
+	}
+	return fmt.Errorf("'%s' is not a valid prediction status", s)
+}
+
+func (p PayloadStreamGoalStarted) Validate() error {
+	var v core.Validator
+	v.Check(p.GoalId != "", "/goal_id", "is required")
+	v.Nested("/type", p.Type.Validate())
+	v.Check(p.CurrentAmount >= 0, "/current_amount", "must not be negative")
+	v.Check(p.TargetAmount > 0, "/target_amount", "must be a positive number")
+	return v.Err()
+}
+
+func (p PayloadStreamGoalProgressed) Validate() error {
+	var v core.Validator
+	v.Check(p.GoalId != "", "/goal_id", "is required")
+	v.Nested("/type", p.Type.Validate())
+	v.Check(p.CurrentAmount >= 0, "/current_amount", "must not be negative")
+	v.Check(p.TargetAmount > 0, "/target_amount", "must be a positive number")
+	return v.Err()
+}
+
+func (p PayloadStreamGoalEnded) Validate() error {
+	var v core.Validator
+	v.Check(p.GoalId != "", "/goal_id", "is required")
+	v.Nested("/type", p.Type.Validate())
+	v.Check(p.CurrentAmount >= 0, "/current_amount", "must not be negative")
+	v.Check(p.TargetAmount > 0, "/target_amount", "must be a positive number")
+	return v.Err()
+}
+
+func (p PayloadViewerRaided) Validate() error {
+	var v core.Validator
+	v.Check(p.NumRaiders >= 0, "/num_raiders", "must not be negative")
+	return v.Err()
+}
+
+func (p PayloadViewerCheered) Validate() error {
+	var v core.Validator
+	v.Check(p.NumBits > 0, "/num_bits", "must be a positive number")
+	v.Nested("/message_fragments", p.MessageFragments.Validate())
+	return v.Err()
+}
+
+func (p PayloadViewerRedeemedFunPoints) Validate() error {
+	var v core.Validator
+	v.Check(p.NumPoints > 0, "/num_points", "must be a positive number")
+	return v.Err()
+}
+
+func (p PayloadViewerRedeemedReward) Validate() error {
+	var v core.Validator
+	v.Check(p.RewardId != "", "/reward_id", "is required")
+	v.Check(p.RewardCost > 0, "/reward_cost", "must be a positive number")
+	return v.Err()
+}
+
+func (p PayloadViewerSubscribed) Validate() error {
+	var v core.Validator
+	if p.Tier != "" {
+		v.Nested("/tier", p.Tier.Validate())
+	}
+	v.Check(p.CreditMultiplier > 0, "/credit_multiplier", "must be a positive number")
+	return v.Err()
+}
+
+func (p PayloadViewerResubscribed) Validate() error {
+	var v core.Validator
+	if p.Tier != "" {
+		v.Nested("/tier", p.Tier.Validate())
+	}
+	v.Check(p.CreditMultiplier > 0, "/credit_multiplier", "must be a positive number")
+	v.Check(p.NumCumulativeMonths > 0, "/num_cumulative_months", "must be a positive number")
+	v.Nested("/message_fragments", p.MessageFragments.Validate())
+	return v.Err()
+}
+
+func (p PayloadViewerReceivedGiftSub) Validate() error {
+	var v core.Validator
+	if p.Tier != "" {
+		v.Nested("/tier", p.Tier.Validate())
+	}
+	v.Check(p.CreditMultiplier > 0, "/credit_multiplier", "must be a positive number")
+	return v.Err()
+}
+
+func (p PayloadViewerGiftedSubs) Validate() error {
+	var v core.Validator
+	if p.Tier != "" {
+		v.Nested("/tier", p.Tier.Validate())
+	}
+	v.Check(p.CreditMultiplier > 0, "/credit_multiplier", "must be a positive number")
+	v.Check(p.NumSubscriptions > 0, "/num_subscriptions", "must be a positive number")
+	return v.Err()
+}
+
+func (p PayloadViewerBanned) Validate() error {
+	var v core.Validator
+	if p.Moderator != nil {
+		v.Nested("/moderator", p.Moderator.Validate())
+	}
+	return v.Err()
+}
+
+func (p PayloadViewerUnbanned) Validate() error {
+	var v core.Validator
+	v.Nested("/moderator", p.Moderator.Validate())
+	return v.Err()
+}
+
+func (p PayloadViewerMessageDeleted) Validate() error {
+	var v core.Validator
+	v.Check(p.MessageId != "", "/message_id", "is required")
+	return v.Err()
+}
+
+func (m MessageFragments) Validate() error {
+	var v core.Validator
+	for i, fragment := range m {
+		v.Nested(fmt.Sprintf("/%d", i), fragment.Validate())
+	}
+	return v.Err()
+}
+
+func (f MessageFragment) Validate() error {
+	var v core.Validator
+	switch f.Type {
+	case MessageFragmentTypeText:
+		v.Check(f.Emote == nil, "/emote", "must be null for text fragments")
+		v.Check(f.Cheermote == nil, "/cheermote", "must be null for text fragments")
+	case MessageFragmentTypeEmote:
+		v.Check(f.Emote != nil && f.Emote.Id != "", "/emote/id", "is required")
+		v.Check(f.Cheermote == nil, "/cheermote", "must be null for emote fragments")
+	case MessageFragmentTypeCheermote:
+		v.Check(f.Emote == nil, "/emote", "must be null for cheermote fragments")
+		v.Check(f.Cheermote != nil && f.Cheermote.NumBits > 0, "/cheermote/num_bits", "must be a positive number")
+	default:
+		v.Check(false, "/type", fmt.Sprintf("'%s' is not a valid message fragment type", f.Type))
+	}
+	return v.Err()
+}
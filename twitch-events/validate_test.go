@@ -0,0 +1,289 @@
+package etwitch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golden-vcr/schemas/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Event_Validate(t *testing.T) {
+	viewer := &core.Viewer{
+		TwitchUserId:      "90790024",
+		TwitchDisplayName: "wasabimilkshake",
+	}
+	tests := []struct {
+		name    string
+		ev      Event
+		wantErr core.ValidationErrors
+	}{
+		{
+			"stream started event",
+			Event{
+				Type: EventTypeStreamStarted,
+				Payload: &Payload{
+					StreamStarted: &PayloadStreamStarted{
+						BroadcasterId: "953753877",
+						StreamId:      "40234719239",
+						StreamType:    StreamTypeLive,
+						StartedAt:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"stream started with no stream ID or start time",
+			Event{
+				Type: EventTypeStreamStarted,
+				Payload: &Payload{
+					StreamStarted: &PayloadStreamStarted{BroadcasterId: "953753877", StreamType: "vhs"},
+				},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/stream_id", Message: "is required"},
+				{Path: "/payload/stream_type", Message: "'vhs' is not a valid stream type"},
+				{Path: "/payload/started_at", Message: "is required"},
+			},
+		},
+		{
+			"anonymous cheer",
+			Event{
+				Type: EventTypeViewerCheered,
+				Payload: &Payload{
+					ViewerCheered: &PayloadViewerCheered{NumBits: 200, Message: "ghost of a seal"},
+				},
+			},
+			nil,
+		},
+		{
+			"hype train ended",
+			Event{
+				Type: EventTypeStreamHypeEnded,
+				Payload: &Payload{
+					StreamHypeEnded: &PayloadStreamHypeEnded{
+						Level: 2,
+						Total: 600,
+						TopContributions: []HypeContribution{
+							{Viewer: *viewer, Type: HypeContributionTypeSubscription, Total: 500},
+						},
+					},
+				},
+			},
+			nil,
+		},
+		{
+			"hype train with invalid contribution",
+			Event{
+				Type: EventTypeStreamHypeProgressed,
+				Payload: &Payload{
+					StreamHypeProgressed: &PayloadStreamHypeProgressed{
+						Level: 1,
+						Goal:  500,
+						TopContributions: []HypeContribution{
+							{Viewer: *viewer, Type: "bits", Total: 100},
+							{Viewer: core.Viewer{TwitchUserId: "123"}, Type: "vibes", Total: 0},
+						},
+					},
+				},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/top_contributions/1/viewer/twitch_display_name", Message: "is required"},
+				{Path: "/payload/top_contributions/1/type", Message: "'vibes' is not a valid hype contribution type"},
+				{Path: "/payload/top_contributions/1/total", Message: "must be a positive number"},
+			},
+		},
+		{
+			"shoutout given with no moderator or start time",
+			Event{
+				Type: EventTypeStreamShoutoutGiven,
+				Payload: &Payload{
+					StreamShoutoutGiven: &PayloadStreamShoutoutGiven{
+						Broadcaster: core.Viewer{TwitchUserId: "626262", TwitchDisplayName: "SandySanderman"},
+						NumViewers:  860,
+					},
+				},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/moderator/twitch_user_id", Message: "is required"},
+				{Path: "/payload/moderator/twitch_display_name", Message: "is required"},
+				{Path: "/payload/started_at", Message: "is required"},
+			},
+		},
+		{
+			"poll ended with no choices and unknown status",
+			Event{
+				Type: EventTypeStreamPollEnded,
+				Payload: &Payload{
+					StreamPollEnded: &PayloadStreamPollEnded{PollId: "1243456", Status: "moderated"},
+				},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/choices", Message: "must not be empty"},
+				{Path: "/payload/status", Message: "'moderated' is not a valid poll status"},
+			},
+		},
+		{
+			"resolved prediction with no winning outcome",
+			Event{
+				Type: EventTypeStreamPredictionEnded,
+				Payload: &Payload{
+					StreamPredictionEnded: &PayloadStreamPredictionEnded{
+						PredictionId: "1243456",
+						Outcomes: []PredictionOutcome{
+							{Id: "12345", Color: PredictionOutcomeColorBlue},
+							{Id: "22435", Color: "green"},
+						},
+						Status: PredictionStatusResolved,
+					},
+				},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/outcomes/1/color", Message: "'green' is not a valid prediction outcome color"},
+				{Path: "/payload/winning_outcome_id", Message: "is required"},
+			},
+		},
+		{
+			"goal with unknown type and no target",
+			Event{
+				Type: EventTypeStreamGoalProgressed,
+				Payload: &Payload{
+					StreamGoalProgressed: &PayloadStreamGoalProgressed{GoalId: "12345-cool-event", Type: "new_raider"},
+				},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/type", Message: "'new_raider' is not a valid goal type"},
+				{Path: "/payload/target_amount", Message: "must be a positive number"},
+			},
+		},
+		{
+			"ban with incomplete moderator",
+			Event{
+				Type:   EventTypeViewerBanned,
+				Viewer: viewer,
+				Payload: &Payload{
+					ViewerBanned: &PayloadViewerBanned{Moderator: &core.Viewer{TwitchDisplayName: "Mod_User"}},
+				},
+			},
+			core.ValidationErrors{{Path: "/payload/moderator/twitch_user_id", Message: "is required"}},
+		},
+		{
+			"cheer with inconsistent message fragments",
+			Event{
+				Type:   EventTypeViewerCheered,
+				Viewer: viewer,
+				Payload: &Payload{
+					ViewerCheered: &PayloadViewerCheered{
+						NumBits: 100,
+						Message: "Cheer100 Kappa",
+						MessageFragments: MessageFragments{
+							{Type: MessageFragmentTypeCheermote, Text: "Cheer100", Emote: &MessageEmote{Id: "25"}},
+							{Type: MessageFragmentTypeEmote, Text: "Kappa"},
+							{Type: "sticker", Text: "seal"},
+						},
+					},
+				},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/message_fragments/0/emote", Message: "must be null for cheermote fragments"},
+				{Path: "/payload/message_fragments/0/cheermote/num_bits", Message: "must be a positive number"},
+				{Path: "/payload/message_fragments/1/emote/id", Message: "is required"},
+				{Path: "/payload/message_fragments/2/type", Message: "'sticker' is not a valid message fragment type"},
+			},
+		},
+		{
+			"unknown event type",
+			Event{Type: "viewer-sneezed", Viewer: viewer},
+			core.ValidationErrors{{Path: "/type", Message: "unknown type 'viewer-sneezed'"}},
+		},
+		{
+			"follow with no viewer",
+			Event{Type: EventTypeViewerFollowed},
+			core.ValidationErrors{{Path: "/viewer", Message: "is required"}},
+		},
+		{
+			"follow with incomplete viewer",
+			Event{Type: EventTypeViewerFollowed, Viewer: &core.Viewer{TwitchUserId: "90790024"}},
+			core.ValidationErrors{{Path: "/viewer/twitch_display_name", Message: "is required"}},
+		},
+		{
+			"cheer with no payload",
+			Event{Type: EventTypeViewerCheered, Viewer: viewer},
+			core.ValidationErrors{{Path: "/payload", Message: "is required for type 'viewer-cheered'"}},
+		},
+		{
+			"cheer with negative bits",
+			Event{
+				Type:   EventTypeViewerCheered,
+				Viewer: viewer,
+				Payload: &Payload{
+					ViewerCheered: &PayloadViewerCheered{NumBits: -200},
+				},
+			},
+			core.ValidationErrors{{Path: "/payload/num_bits", Message: "must be a positive number"}},
+		},
+		{
+			"reward redemption with no reward ID or cost",
+			Event{
+				Type:   EventTypeViewerRedeemedReward,
+				Viewer: viewer,
+				Payload: &Payload{
+					ViewerRedeemedReward: &PayloadViewerRedeemedReward{RewardTitle: "Summon a ghost"},
+				},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/reward_id", Message: "is required"},
+				{Path: "/payload/reward_cost", Message: "must be a positive number"},
+			},
+		},
+		{
+			"subscription that predates sub tiers",
+			Event{
+				Type:   EventTypeViewerSubscribed,
+				Viewer: viewer,
+				Payload: &Payload{
+					ViewerSubscribed: &PayloadViewerSubscribed{CreditMultiplier: 1},
+				},
+			},
+			nil,
+		},
+		{
+			"gifted subs with invalid tier, and zero subscriptions and multiplier",
+			Event{
+				Type:   EventTypeViewerGiftedSubs,
+				Viewer: viewer,
+				Payload: &Payload{
+					ViewerGiftedSubs: &PayloadViewerGiftedSubs{Tier: "4000"},
+				},
+			},
+			core.ValidationErrors{
+				{Path: "/payload/tier", Message: "'4000' is not a valid sub tier"},
+				{Path: "/payload/credit_multiplier", Message: "must be a positive number"},
+				{Path: "/payload/num_subscriptions", Message: "must be a positive number"},
+			},
+		},
+		{
+			"payload with two variants",
+			Event{
+				Type:   EventTypeViewerRaided,
+				Viewer: viewer,
+				Payload: &Payload{
+					ViewerRaided:  &PayloadViewerRaided{NumRaiders: 5},
+					ViewerCheered: &PayloadViewerCheered{NumBits: 100},
+				},
+			},
+			core.ValidationErrors{{Path: "/payload", Message: "must populate a single variant (got ViewerRaided, ViewerCheered)"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ev.Validate()
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Code generated by 'go run ./cmd/typescript' in github.com/golden-vcr/schemas. DO NOT EDIT.
+
+export type EventType =
+  | 'broadcast-started'
+  | 'broadcast-finished'
+  | 'screening-started'
+  | 'screening-finished';
+
+export interface Envelope {
+  message_id: string;
+  correlation_id: string;
+  causation_id?: string | null;
+  produced_at: string;
+  producer: string;
+  schema_version: number;
+  body: Event;
+}
+
+export interface Event {
+  type: EventType;
+  broadcast: BroadcastData;
+  screening?: ScreeningData | null;
+}
+
+export interface BroadcastData {
+  id: number;
+  started_at: string;
+}
+
+export interface ScreeningData {
+  id: string;
+  started_at: string;
+  tape_id: number;
+}
@@ -0,0 +1,280 @@
+// Code generated by 'go run ./cmd/typescript' in github.com/golden-vcr/schemas. DO NOT EDIT.
+
+export type EventType =
+  | 'status'
+  | 'toast'
+  | 'image'
+  | 'poll'
+  | 'goal';
+
+export type ToastType =
+  | 'followed'
+  | 'raided'
+  | 'cheered'
+  | 'subscribed'
+  | 'resubscribed'
+  | 'gifted-subs'
+  | 'shouted-out';
+
+export type ImageType =
+  | 'static'
+  | 'ghost'
+  | 'friend';
+
+export type GoalType =
+  | 'follow'
+  | 'subscription'
+  | 'subscription_count'
+  | 'new_subscription'
+  | 'new_subscription_count'
+  | 'new_bit'
+  | 'new_cheerer';
+
+export interface Envelope {
+  message_id: string;
+  correlation_id: string;
+  causation_id?: string | null;
+  produced_at: string;
+  producer: string;
+  schema_version: number;
+  body: Event;
+}
+
+export type Event =
+  | EventStatus
+  | EventToast
+  | EventImage
+  | EventPoll
+  | EventGoal;
+
+export interface EventStatus {
+  type: 'status';
+  payload: PayloadStatus;
+}
+
+export interface EventToast {
+  type: 'toast';
+  payload: PayloadToast;
+}
+
+export interface EventImage {
+  type: 'image';
+  payload: PayloadImage;
+}
+
+export interface EventPoll {
+  type: 'poll';
+  payload: PayloadPoll;
+}
+
+export interface EventGoal {
+  type: 'goal';
+  payload: PayloadGoal;
+}
+
+export function isEventStatus(value: Event): value is EventStatus {
+  return value.type === 'status';
+}
+
+export function isEventToast(value: Event): value is EventToast {
+  return value.type === 'toast';
+}
+
+export function isEventImage(value: Event): value is EventImage {
+  return value.type === 'image';
+}
+
+export function isEventPoll(value: Event): value is EventPoll {
+  return value.type === 'poll';
+}
+
+export function isEventGoal(value: Event): value is EventGoal {
+  return value.type === 'goal';
+}
+
+export interface PayloadStatus {
+  current_tape_id: number;
+}
+
+export type PayloadToast =
+  | PayloadToastFollowed
+  | PayloadToastRaided
+  | PayloadToastCheered
+  | PayloadToastSubscribed
+  | PayloadToastResubscribed
+  | PayloadToastGiftedSubs
+  | PayloadToastShoutedOut;
+
+export interface PayloadToastFollowed {
+  type: 'followed';
+  viewer: Viewer | null;
+  data?: null;
+}
+
+export interface PayloadToastRaided {
+  type: 'raided';
+  viewer: Viewer | null;
+  data: ToastDataRaided;
+}
+
+export interface PayloadToastCheered {
+  type: 'cheered';
+  viewer: Viewer | null;
+  data: ToastDataCheered;
+}
+
+export interface PayloadToastSubscribed {
+  type: 'subscribed';
+  viewer: Viewer | null;
+  data?: null;
+}
+
+export interface PayloadToastResubscribed {
+  type: 'resubscribed';
+  viewer: Viewer | null;
+  data: ToastDataResubscribed;
+}
+
+export interface PayloadToastGiftedSubs {
+  type: 'gifted-subs';
+  viewer: Viewer | null;
+  data: ToastDataGiftedSubs;
+}
+
+export interface PayloadToastShoutedOut {
+  type: 'shouted-out';
+  viewer: Viewer | null;
+  data: ToastDataShoutedOut;
+}
+
+export function isPayloadToastFollowed(value: PayloadToast): value is PayloadToastFollowed {
+  return value.type === 'followed';
+}
+
+export function isPayloadToastRaided(value: PayloadToast): value is PayloadToastRaided {
+  return value.type === 'raided';
+}
+
+export function isPayloadToastCheered(value: PayloadToast): value is PayloadToastCheered {
+  return value.type === 'cheered';
+}
+
+export function isPayloadToastSubscribed(value: PayloadToast): value is PayloadToastSubscribed {
+  return value.type === 'subscribed';
+}
+
+export function isPayloadToastResubscribed(value: PayloadToast): value is PayloadToastResubscribed {
+  return value.type === 'resubscribed';
+}
+
+export function isPayloadToastGiftedSubs(value: PayloadToast): value is PayloadToastGiftedSubs {
+  return value.type === 'gifted-subs';
+}
+
+export function isPayloadToastShoutedOut(value: PayloadToast): value is PayloadToastShoutedOut {
+  return value.type === 'shouted-out';
+}
+
+export interface Viewer {
+  twitch_user_id: string;
+  twitch_display_name: string;
+}
+
+export interface ToastDataRaided {
+  num_viewers: number;
+}
+
+export interface ToastDataCheered {
+  num_bits: number;
+  message: string;
+}
+
+export interface ToastDataResubscribed {
+  num_cumulative_months: number;
+  message: string;
+}
+
+export interface ToastDataGiftedSubs {
+  num_subscriptions: number;
+}
+
+export interface ToastDataShoutedOut {
+  num_viewers: number;
+}
+
+export type PayloadImage =
+  | PayloadImageStatic
+  | PayloadImageGhost
+  | PayloadImageFriend;
+
+export interface PayloadImageStatic {
+  type: 'static';
+  viewer: Viewer;
+  details: ImageDetailsStatic;
+}
+
+export interface PayloadImageGhost {
+  type: 'ghost';
+  viewer: Viewer;
+  details: ImageDetailsGhost;
+}
+
+export interface PayloadImageFriend {
+  type: 'friend';
+  viewer: Viewer;
+  details: ImageDetailsFriend;
+}
+
+export function isPayloadImageStatic(value: PayloadImage): value is PayloadImageStatic {
+  return value.type === 'static';
+}
+
+export function isPayloadImageGhost(value: PayloadImage): value is PayloadImageGhost {
+  return value.type === 'ghost';
+}
+
+export function isPayloadImageFriend(value: PayloadImage): value is PayloadImageFriend {
+  return value.type === 'friend';
+}
+
+export interface ImageDetailsStatic {
+  image_id: string;
+  message: string;
+}
+
+export interface ImageDetailsGhost {
+  image_url: string;
+  description: string;
+}
+
+export interface ImageDetailsFriend {
+  image_url: string;
+  description: string;
+  name: string;
+  background_color: string;
+}
+
+export interface PayloadPoll {
+  poll_id: string;
+  title: string;
+  choices: PollChoice[] | null;
+  ends_at: string;
+  is_ended: boolean;
+}
+
+export interface PollChoice {
+  id: string;
+  title: string;
+  num_votes: number;
+  num_channel_points_votes: number;
+}
+
+export interface PayloadGoal {
+  goal_id: string;
+  type: GoalType;
+  description: string;
+  current_amount: number;
+  target_amount: number;
+  is_ended: boolean;
+  is_achieved: boolean;
+}